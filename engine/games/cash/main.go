@@ -0,0 +1,301 @@
+package cash
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games"
+	tableMessages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/rake"
+	"github.com/luismasuelli/poker-go/engine/misc"
+	"github.com/luismasuelli/poker-go/engine/players"
+	. "sync"
+)
+
+var ErrNoBuyIns = errors.New("a cash game needs at least one buy-in")
+var ErrNotACurrency = errors.New("only currencies can be used as buy-ins of cash games")
+var ErrTooFewSeats = errors.New("the tables of a cash game need at least 2 seats")
+var ErrCurrencyNotAccepted = errors.New("the currency is not accepted by this game")
+var ErrBuyInOutOfRange = errors.New("the buy-in amount is out of the allowed range")
+var ErrAlreadyPlaying = errors.New("the player is already sitting in this game")
+var ErrNotSitting = errors.New("there is no player sitting in that seat")
+
+// Prepares each new table of a cash game (e.g. sets its
+// dealer, with the stakes and the driver of the variant,
+// and its hand template). All the tables of the game are
+// prepared by the same function, so they have the same
+// stakes.
+type Setup func(table *tables.Table)
+
+// A player sitting in a seat of the game, the table, and
+// the currency of the buy-in. Players joining or leaving
+// while a hand is played are seated or released before the
+// next hand.
+type sitting struct {
+	table    *tables.Table
+	player   players.Player
+	currency assets.Asset
+	amount   uint64
+	seated   bool
+	leaving  bool
+}
+
+// A cash game plays in one or more tables of the same stakes
+// and number of seats. Players buy in chips (1:1) with any of
+// the accepted currencies, and get the stack refunded in the
+// same currency when they leave. When all the tables are full,
+// a new one is opened for the next player joining.
+// The hands may be raked for the house.
+type Game struct {
+	id       interface{}
+	caption  string
+	buyIns   assets.BuyIns
+	size     int
+	setup    Setup
+	parent   misc.Notifiable
+	tables   []*tables.Table
+	sittings map[seats.Seat]*sitting
+	rake     *rake.Rake
+	house    players.Accounting
+	currency assets.Asset
+	mutex    Mutex
+}
+
+// Creates a new cash game with its buy-ins (only currencies
+// are allowed), the number of seats per table, the setup of
+// the tables, and the parent notifiable of their messages.
+// The first table is opened when the first player joins.
+func NewGame(id interface{}, caption string, buyIns assets.BuyIns, size int, setup Setup, parent misc.Notifiable) (*Game, error) {
+	if len(buyIns) == 0 {
+		return nil, ErrNoBuyIns
+	}
+	for asset := range buyIns {
+		if asset.Type() != assets.Currency {
+			return nil, ErrNotACurrency
+		}
+	}
+	if size < 2 {
+		return nil, ErrTooFewSeats
+	}
+	return &Game{
+		id:       id,
+		caption:  caption,
+		buyIns:   buyIns,
+		size:     size,
+		setup:    setup,
+		parent:   parent,
+		sittings: map[seats.Seat]*sitting{},
+	}, nil
+}
+
+// The ID of the game.
+func (game *Game) ID() interface{} {
+	return game.id
+}
+
+// The caption of the game.
+func (game *Game) Caption() string {
+	return game.caption
+}
+
+// The occupied seats (including the players about to sit
+// or leave) and the total seats among all the tables.
+func (game *Game) Occupancy() (uint32, uint32) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return uint32(len(game.sittings)), uint32(len(game.tables) * game.size)
+}
+
+// Cash games are always playing.
+func (game *Game) Status() games.GameStatus {
+	return games.Playing
+}
+
+// The tables of the game.
+func (game *Game) Tables() []*tables.Table {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return append([]*tables.Table{}, game.tables...)
+}
+
+// Opens a new table, prepared by the setup.
+func (game *Game) open() *tables.Table {
+	table := tables.NewTable(game.id, engine.TableID(len(game.tables)+1), game.size, game.parent)
+	if game.setup != nil {
+		game.setup(table)
+	}
+	game.raking(table)
+	table.BeforeHand = func(table *tables.Table) {
+		game.mutex.Lock()
+		defer game.mutex.Unlock()
+		game.raking(table)
+		game.apply(table)
+	}
+	stopped := table.Stopped
+	table.Stopped = func(table *tables.Table, reason interface{}) {
+		game.mutex.Lock()
+		if seated, _ := game.apply(table); seated && reason == nil {
+			table.Start()
+		}
+		game.mutex.Unlock()
+		if stopped != nil {
+			stopped(table, reason)
+		}
+	}
+	game.tables = append(game.tables, table)
+	return table
+}
+
+// Sets the rake of the tables, from their next hand on, and
+// the house credited with it in one of the accepted currencies
+// (a nil rake means no rake).
+func (game *Game) SetRake(tableRake *rake.Rake, house players.Accounting, currency assets.Asset) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if _, ok := game.buyIns[currency]; tableRake != nil && !ok {
+		return ErrCurrencyNotAccepted
+	}
+	game.rake, game.house, game.currency = tableRake, house, currency
+	return nil
+}
+
+// Sets the rake in the hand settings of a table. It must be
+// called with the game locked, and while the table plays no
+// hand.
+func (game *Game) raking(table *tables.Table) {
+	table.Template.Rake = game.rake
+	table.Template.House = game.house
+	table.Template.Asset = game.currency
+}
+
+// Gets the first free seat among the tables.
+func (game *Game) free() (*tables.Table, seats.Seat) {
+	for _, table := range game.tables {
+		for _, seat := range table.Seats() {
+			if _, ok := game.sittings[seat]; !ok {
+				return table, seat
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Takes the buy-in from the player, and sits it in the first
+// free seat (opening a new table if all of them are full). The
+// player is seated right away if the table is not playing, or
+// before its next hand otherwise.
+func (game *Game) Join(player players.Player, currency assets.Asset, amount uint64) (*tables.Table, seats.Seat, error) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	buyIn, ok := game.buyIns[currency]
+	if !ok {
+		return nil, nil, ErrCurrencyNotAccepted
+	}
+	if amount < buyIn.MinAmount() || amount > buyIn.MaxAmount() {
+		return nil, nil, ErrBuyInOutOfRange
+	}
+	for _, sitting := range game.sittings {
+		if sitting.player.Identification() == player.Identification() {
+			return nil, nil, ErrAlreadyPlaying
+		}
+	}
+	if err := player.Take(currency, amount); err != nil {
+		return nil, nil, err
+	}
+	table, seat := game.free()
+	if table == nil {
+		table = game.open()
+		seat = table.Seats()[0]
+	}
+	game.sittings[seat] = &sitting{table: table, player: player, currency: currency, amount: amount}
+	if !table.Running() {
+		game.apply(table)
+		table.Start()
+	}
+	return table, seat, nil
+}
+
+// Releases the seat, refunding its stack. The player leaves
+// right away if the table is not playing, or before its next
+// hand otherwise. When the player leaves right away, a failed
+// refund is also returned.
+func (game *Game) Leave(seat seats.Seat) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	sitting, ok := game.sittings[seat]
+	if !ok {
+		return ErrNotSitting
+	}
+	sitting.leaving = true
+	if !sitting.table.Running() {
+		_, err := game.apply(sitting.table)
+		return err
+	}
+	return nil
+}
+
+// Refunds chips to a player, in the currency of its buy-in.
+// A failed refund is announced to the table (and so to the
+// parent of the game), so it can be settled later.
+func (game *Game) refund(table *tables.Table, sitting *sitting, chips uint64) error {
+	if chips == 0 {
+		return nil
+	}
+	err := sitting.player.Add(sitting.currency, chips)
+	if err != nil {
+		table.Broadcaster().NotifyTable(game.id, uint32(table.ID()), tableMessages.RefundHasFailed{
+			Player: sitting.player.Identification(),
+			Asset:  sitting.currency.ID(),
+			Amount: chips,
+			Reason: err.Error(),
+		})
+	}
+	return err
+}
+
+// Seats the players who joined the table and releases the
+// ones who left, refunding their stacks in the currency of
+// their buy-ins. It must be called with the game locked, and
+// while the table plays no hand. Tells whether a player was
+// seated, and the first failed refund.
+func (game *Game) apply(table *tables.Table) (bool, error) {
+	seated := false
+	var failed error
+	gameID, tableID := game.id, uint32(table.ID())
+	for _, seat := range table.Seats() {
+		sitting, ok := game.sittings[seat]
+		if !ok {
+			continue
+		}
+		if sitting.leaving {
+			stack := sitting.amount
+			if sitting.seated {
+				_, stack = seat.Pop()
+				table.Broadcaster().NotifySeat(gameID, tableID, seat.SeatID(), messages.SeatHasBeenReleased{})
+			}
+			if err := game.refund(table, sitting, stack); err != nil && failed == nil {
+				failed = err
+			}
+			delete(game.sittings, seat)
+		} else if !sitting.seated {
+			if err := seat.Sit(sitting.player, sitting.amount); err != nil {
+				if err := game.refund(table, sitting, sitting.amount); err != nil && failed == nil {
+					failed = err
+				}
+				delete(game.sittings, seat)
+				continue
+			}
+			sitting.seated = true
+			sitting.amount = 0
+			seated = true
+			table.Broadcaster().NotifySeat(gameID, tableID, seat.SeatID(), messages.SeatHasBeenOccupied{
+				PlayerDisplay: sitting.player.Display(),
+				Stack:         seat.Stack(),
+			})
+		}
+	}
+	return seated, failed
+}
@@ -0,0 +1,283 @@
+package cash
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	tableMessages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/rake"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"sync"
+	"testing"
+	"time"
+)
+
+type asset struct {
+	id        string
+	assetType assets.AssetType
+}
+
+func (asset *asset) ID() interface{}        { return asset.id }
+func (asset *asset) Type() assets.AssetType { return asset.assetType }
+func (asset *asset) Caption() string        { return asset.id }
+
+var errCannotAfford = errors.New("cannot afford")
+
+type player struct {
+	balances map[assets.Asset]uint64
+	refusing error
+	mutex    sync.Mutex
+}
+
+func newPlayer(currency assets.Asset, balance uint64) *player {
+	return &player{balances: map[assets.Asset]uint64{currency: balance}}
+}
+
+func (player *player) Identification() interface{}                        { return player }
+func (player *player) Display() interface{}                               { return player }
+func (player *player) Notify(message interface{})                         {}
+func (player *player) FindHaving(asset assets.Asset) []players.Accounting { return nil }
+
+func (player *player) Get(asset assets.Asset) (uint64, error) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	return player.balances[asset], nil
+}
+
+func (player *player) Add(asset assets.Asset, amount uint64) error {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	if player.refusing != nil {
+		return player.refusing
+	}
+	player.balances[asset] += amount
+	return nil
+}
+
+func (player *player) Take(asset assets.Asset, amount uint64) error {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	if player.balances[asset] < amount {
+		return errCannotAfford
+	}
+	player.balances[asset] -= amount
+	return nil
+}
+
+// Keeps the failed refunds.
+type refundsRecorder []tableMessages.RefundHasFailed
+
+func (recorder *refundsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tableMessages.TableMessage).Content
+	if failed, ok := content.(tableMessages.RefundHasFailed); ok {
+		*recorder = append(*recorder, failed)
+	}
+}
+
+var dollars = &asset{"USD", assets.Currency}
+
+func buyIns() assets.BuyIns {
+	return assets.BuyIns{dollars: *assets.NewBuyIn(dollars, 400, 1000)}
+}
+
+func checkBalance(t *testing.T, player *player, expected uint64) {
+	if balance, _ := player.Get(dollars); balance != expected {
+		t.Errorf("expected a balance of %d, got %d", expected, balance)
+	}
+}
+
+func checkOccupancy(t *testing.T, game *Game, occupied, total uint32) {
+	if o, tt := game.Occupancy(); o != occupied || tt != total {
+		t.Errorf("expected occupancy %d/%d, got %d/%d", occupied, total, o, tt)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	ticket := &asset{"TICKET", assets.Ticket}
+	if _, err := NewGame(1, "NL", assets.BuyIns{}, 6, nil, &seatstest.Notifiable{}); err != ErrNoBuyIns {
+		t.Errorf("expected ErrNoBuyIns, got %v", err)
+	}
+	ticketBuyIns := assets.BuyIns{ticket: *assets.NewBuyIn(ticket, 1, 1)}
+	if _, err := NewGame(1, "NL", ticketBuyIns, 6, nil, &seatstest.Notifiable{}); err != ErrNotACurrency {
+		t.Errorf("expected ErrNotACurrency, got %v", err)
+	}
+	if _, err := NewGame(1, "NL", buyIns(), 1, nil, &seatstest.Notifiable{}); err != ErrTooFewSeats {
+		t.Errorf("expected ErrTooFewSeats, got %v", err)
+	}
+	game, err := NewGame(1, "NL", buyIns(), 6, nil, &seatstest.Notifiable{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkOccupancy(t, game, 0, 0)
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	// Hands are never played: 3 players are required in
+	// tables of 2 seats.
+	game, _ := NewGame(1, "NL", buyIns(), 2, func(table *tables.Table) {
+		table.MinPlayers = 3
+	}, &seatstest.Notifiable{})
+	alice, bob := newPlayer(dollars, 2000), newPlayer(dollars, 2000)
+	if _, _, err := game.Join(alice, &asset{"EUR", assets.Currency}, 500); err != ErrCurrencyNotAccepted {
+		t.Errorf("expected ErrCurrencyNotAccepted, got %v", err)
+	}
+	if _, _, err := game.Join(alice, dollars, 1500); err != ErrBuyInOutOfRange {
+		t.Errorf("expected ErrBuyInOutOfRange, got %v", err)
+	}
+	if _, _, err := game.Join(newPlayer(dollars, 100), dollars, 500); err != errCannotAfford {
+		t.Errorf("expected the accounting error, got %v", err)
+	}
+	checkOccupancy(t, game, 0, 0)
+
+	_, aliceSeat, err := game.Join(alice, dollars, 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkBalance(t, alice, 1500)
+	if aliceSeat.Player() != alice || aliceSeat.Stack() != 500 {
+		t.Errorf("expected alice to sit with 500 chips")
+	}
+	checkOccupancy(t, game, 1, 2)
+	if _, _, err := game.Join(alice, dollars, 500); err != ErrAlreadyPlaying {
+		t.Errorf("expected ErrAlreadyPlaying, got %v", err)
+	}
+
+	// The first table becomes full. A new one is opened
+	// for the next player joining, but not if it cannot
+	// pay the buy-in.
+	if _, _, err := game.Join(bob, dollars, 1000); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkOccupancy(t, game, 2, 2)
+	if _, _, err := game.Join(newPlayer(dollars, 100), dollars, 500); err != errCannotAfford {
+		t.Errorf("expected the accounting error, got %v", err)
+	}
+	checkOccupancy(t, game, 2, 2)
+	carolTable, _, err := game.Join(newPlayer(dollars, 500), dollars, 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkOccupancy(t, game, 3, 4)
+	if opened := game.Tables(); len(opened) != 2 || opened[1] != carolTable || carolTable.ID() != 2 {
+		t.Errorf("expected a second table to be opened, got %d tables", len(opened))
+	}
+
+	if err := game.Leave(aliceSeat); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkBalance(t, alice, 2000)
+	if aliceSeat.Player() != nil {
+		t.Errorf("expected the seat to be released")
+	}
+	checkOccupancy(t, game, 2, 4)
+	if err := game.Leave(aliceSeat); err != ErrNotSitting {
+		t.Errorf("expected ErrNotSitting, got %v", err)
+	}
+}
+
+func TestFailedRefund(t *testing.T) {
+	var recorder refundsRecorder
+	game, _ := NewGame(1, "NL", buyIns(), 2, nil, &recorder)
+	alice := newPlayer(dollars, 500)
+	_, seat, err := game.Join(alice, dollars, 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	refusing := errors.New("account closed")
+	alice.refusing = refusing
+	if err := game.Leave(seat); err != refusing {
+		t.Errorf("expected the refund error, got %v", err)
+	}
+	if len(recorder) != 1 || recorder[0].Player != alice || recorder[0].Asset != "USD" ||
+		recorder[0].Amount != 500 || recorder[0].Reason != "account closed" {
+		t.Errorf("expected the failed refund to be announced: %+v", recorder)
+	}
+}
+
+func TestRake(t *testing.T) {
+	played := make(chan *hands.Hand, 1)
+	game, _ := NewGame(1, "NL", buyIns(), 2, func(table *tables.Table) {
+		table.Dealer = tables.DealerFunc(func(hand *hands.Hand) error {
+			played <- hand
+			table.Stop()
+			return nil
+		})
+	}, &seatstest.Notifiable{})
+	tableRake, house := rake.NewRake(500, 0, true), newPlayer(dollars, 0)
+	if err := game.SetRake(tableRake, house, &asset{"EUR", assets.Currency}); err != ErrCurrencyNotAccepted {
+		t.Errorf("expected ErrCurrencyNotAccepted, got %v", err)
+	}
+	if err := game.SetRake(tableRake, house, dollars); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	game.Join(newPlayer(dollars, 1000), dollars, 1000)
+	game.Join(newPlayer(dollars, 1000), dollars, 1000)
+	select {
+	case hand := <-played:
+		if hand.Rake != tableRake || hand.House != house || hand.Asset != dollars {
+			t.Errorf("expected the hand to be raked for the house")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected a hand to be played")
+	}
+}
+
+func TestJoinWhilePlaying(t *testing.T) {
+	played := make(chan *hands.Hand, 10)
+	proceed := make(chan bool)
+	stopped := make(chan interface{}, 1)
+	game, _ := NewGame(1, "NL", buyIns(), 3, func(table *tables.Table) {
+		table.Dealer = tables.DealerFunc(func(hand *hands.Hand) error {
+			played <- hand
+			<-proceed
+			return nil
+		})
+		table.Stopped = func(table *tables.Table, reason interface{}) {
+			stopped <- reason
+		}
+	}, &seatstest.Notifiable{})
+	alice, bob, carol := newPlayer(dollars, 1000), newPlayer(dollars, 1000), newPlayer(dollars, 1000)
+	table, aliceSeat, _ := game.Join(alice, dollars, 1000)
+	game.Join(bob, dollars, 1000)
+	waitHand := func() {
+		select {
+		case <-played:
+		case <-time.After(time.Second):
+			t.Fatalf("expected a hand to be played")
+		}
+	}
+	waitHand()
+
+	// During the hand, carol joins and alice leaves: both
+	// changes wait for the next hand.
+	_, carolSeat, _ := game.Join(carol, dollars, 800)
+	game.Leave(aliceSeat)
+	checkBalance(t, carol, 200)
+	checkBalance(t, alice, 0)
+	if carolSeat.Player() != nil || aliceSeat.Player() != alice {
+		t.Errorf("expected the seats to remain unchanged during the hand")
+	}
+	checkOccupancy(t, game, 3, 3)
+
+	proceed <- true
+	waitHand()
+	if carolSeat.Player() != carol || carolSeat.Stack() != 800 {
+		t.Errorf("expected carol to sit with 800 chips")
+	}
+	if aliceSeat.Player() != nil {
+		t.Errorf("expected alice to leave")
+	}
+	checkBalance(t, alice, 1000)
+	checkOccupancy(t, game, 2, 3)
+
+	table.Stop()
+	proceed <- true
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the table to stop")
+	}
+}
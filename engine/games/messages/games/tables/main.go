@@ -1,6 +1,9 @@
 package tables
 
-import "github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+)
 
 // Table messages will be related to a
 // table (in a particular game - this
@@ -15,9 +18,122 @@ type TableMessage struct {
 }
 
 // Tells when a showdown will occur or
-// be skipped.
+// be skipped, and in which board (when
+// the board is run more than once).
 type Showdown struct {
 	HandID  uint64
+	Board   uint8
 	Mode    showdowns.Mode
 	Skipped bool
-}
\ No newline at end of file
+}
+
+// Tells how many chips were taken as rake
+// from the pots of a hand.
+type RakeHasBeenTaken struct {
+	HandID uint64
+	Amount uint64
+}
+
+// Tells when the rake of a hand could not be
+// credited to the house, so no rake was taken.
+type RakeHasFailed struct {
+	HandID uint64
+	Reason string
+}
+
+// The rake of a hand, attributed to one of the
+// players dealt in, under the "contributed" (in
+// proportion to the chips put in each raked pot)
+// and "dealt" (evenly among the players dealt in)
+// methods.
+type RakeShare struct {
+	SeatID      uint8
+	Player      interface{}
+	Contributed uint64
+	Dealt       uint64
+}
+
+// Tells how the rake of a hand is attributed to
+// the players dealt in. This message is intended
+// for accounting (e.g. rakeback and loyalty), and
+// not for the players at the table.
+type RakeHasBeenAttributed struct {
+	HandID uint64
+	Shares []RakeShare
+}
+
+// Tells how many chips were dropped from the
+// pots of a hand to fund a promotion pool.
+type PromotionDropHasBeenTaken struct {
+	HandID uint64
+	Amount uint64
+}
+
+// Tells when the bad beat jackpot was hit in
+// a hand, and the whole amount being paid.
+type BadBeatJackpotHasBeenHit struct {
+	HandID uint64
+	Amount uint64
+}
+
+// Tells when a seat holds the best qualifying
+// hand for the high hand promotion.
+type HighHandHasBeenRecorded struct {
+	HandID uint64
+	SeatID uint8
+	Cards  []cards.Card
+}
+
+// Tells when the high hand promotion pool was
+// awarded to the holder of the high hand.
+type HighHandHasBeenAwarded struct {
+	HandID uint64
+	Player interface{}
+	Amount uint64
+}
+
+// Tells when community cards were dealt to
+// the board in a hand. When the board is run
+// more than once, it tells which one (the
+// first board is 0).
+type CommunityCardsHaveBeenDealt struct {
+	HandID uint64
+	Board  uint8
+	Cards  []cards.Card
+}
+
+// Tells when the seats involved in an all-in
+// agreed to run the rest of the board more
+// than once, and how many times.
+type RunsHaveBeenAgreed struct {
+	HandID uint64
+	Runs   uint8
+}
+
+// Tells the variant to be played in the next hands of
+// a mixed games table, and the number of hands of it.
+type NextVariantHasBeenAnnounced struct {
+	Name  string
+	Hands int
+}
+
+// Tells the blinds (and ante) of the new level of a
+// tournament's blind schedule, from the next hand on.
+type BlindsHaveBeenRaised struct {
+	Level int
+	Small uint64
+	Big   uint64
+	Ante  uint64
+}
+
+// Tells when a refund to a player (e.g. its chips
+// when leaving a cash table, or its buy-in when a
+// tournament is cancelled) could not be credited
+// to its account, so it can be settled later. The
+// player and the asset are given by their IDs.
+type RefundHasFailed struct {
+	Player interface{}
+	Asset  interface{}
+	Amount uint64
+	Reason string
+}
@@ -1,7 +1,9 @@
 package seats
 
 import (
+	"github.com/luismasuelli/poker-go/engine"
 	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
 	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
 	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
 )
@@ -103,10 +105,81 @@ type YouGaveCards struct {
 // Tells which sit player, in a hand
 // and showdown mode, won or split
 // the given pot, and which amount.
+// When the board is run more than
+// once, it also tells which board.
 type PlayerWonChips struct {
 	Display  interface{}
 	HandID   uint64
+	Board    uint8
 	Mode     showdowns.Mode
 	PotIndex uint8
 	Prize    uint64
-}
\ No newline at end of file
+}
+
+// Tells when the part of a bet that no other
+// seat could call is given back to the seat's
+// stack, at the end of a betting round.
+type SeatUncalledBetHasBeenReturned struct {
+	Chips      uint64
+	FinalStack uint64
+}
+
+// Tells which sit player, in a hand, won a
+// prize of a promotion (e.g. a share of the
+// bad beat jackpot). Promotion prizes are not
+// given as chips, but credited to the player.
+type PlayerWonPromotion struct {
+	Display interface{}
+	HandID  uint64
+	Prize   uint64
+}
+
+// Tells when a player of a tournament lost all
+// its chips, and the place it finished in.
+type PlayerHasBeenEliminated struct {
+	Display interface{}
+	Place   int
+}
+
+// Tells when a seat took an action in a
+// betting round, the chips it added with
+// that action, and its final pot (for the
+// current round) and stack.
+type SeatHasActed struct {
+	PlayerDisplay interface{}
+	Action        actions.Action
+	Chips         uint64
+	FinalPot      uint64
+	FinalStack    uint64
+}
+
+// Tells when a seat posted a forced bet, the
+// chips it added (which may be less than the
+// required amount, if the seat went all-in),
+// and its final pot and stack.
+type SeatHasPosted struct {
+	PlayerDisplay interface{}
+	Forced        actions.Forced
+	Chips         uint64
+	FinalPot      uint64
+	FinalStack    uint64
+}
+
+// Tells when a seat showed its cards (e.g.
+// at showdown). When only some cards are
+// shown, the other ones are nil.
+type SeatHasShownCards struct {
+	Cards []cards.Card
+}
+
+// Tells when a seat mucked its cards at the
+// showdown, without showing them.
+type SeatHasMuckedCards struct{}
+
+// Tells the player of a seat that its action,
+// in response to a particular request, was not
+// allowed (and why), so it must act again.
+type YourActionHasBeenRejected struct {
+	RequestID engine.RequestID
+	Reason    string
+}
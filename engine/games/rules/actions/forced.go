@@ -0,0 +1,36 @@
+package actions
+
+// Forced bets a seat may post, either before the cards
+// are dealt (blinds and antes) or after the first cards
+// are dealt (the bring-in, in stud games).
+type Forced uint8
+
+const (
+	// The small blind, which is a live bet.
+	SmallBlind Forced = iota
+	// The big blind, which is a live bet and
+	// sets the current bet of the first round.
+	BigBlind
+	// An ante, posted by each seat dealt in. It
+	// is dead money.
+	Ante
+	// An ante posted by the big blind on behalf
+	// of all the seats. It is dead money.
+	BigBlindAnte
+	// An ante posted by the button on behalf of
+	// all the seats. It is dead money.
+	ButtonAnte
+	// The bring-in, in stud games: a live bet,
+	// smaller than the small bet.
+	BringIn
+	// The bring-in seat chose to complete the
+	// bet to the small bet, instead.
+	Completion
+	// A missed small blind, posted by a seat
+	// coming back to play. It is dead money.
+	DeadSmallBlind
+	// A voluntary blind raise (at least twice
+	// the big blind). It is a live bet, and the
+	// seat acts last before the flop.
+	Straddle
+)
@@ -0,0 +1,27 @@
+package actions
+
+// Actions a seat may take in a betting round. Bets
+// and raises involve an amount, which is always the
+// total amount the seat will have put in the current
+// round after the action (i.e. "raise to" amounts),
+// and not the amount being added.
+type Action uint8
+
+const (
+	// The seat leaves the hand.
+	Fold Action = iota
+	// The seat does not bet, since there is
+	// nothing to call.
+	Check
+	// The seat matches the current bet (or
+	// puts all its stack, if it cannot afford
+	// it).
+	Call
+	// The seat opens the betting in the round.
+	Bet
+	// The seat increases the current bet.
+	Raise
+	// The seat puts all its stack. This may
+	// end as a call, a bet, or a raise.
+	AllIn
+)
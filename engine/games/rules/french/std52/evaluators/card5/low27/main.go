@@ -0,0 +1,33 @@
+package low27
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+)
+
+// The powers of a 5-high straight (and straight flush),
+// and the rank bits of the same cards (A-5-4-3-2) when
+// the Ace counts only high.
+const wheel = 4<<39 | 0b0000000001000
+const steelWheel = 8<<39 | 0b0000000001000
+const aceFive = 0b1000000001111
+
+// Computes the power of a hand using the deuce-to-seven
+// lowball metric. This means: the hand is converted to
+// A-high ranks, suits are kept, and straights are also
+// considered (but A-2-3-4-5 is not a straight, since the
+// Ace counts only high). The LOWER power wins, so the best
+// hand is 7-5-4-3-2 (not suited). The result is returned
+// alongside a 0b11111 flag telling all the involved cards
+// (in this case: just the hand cards) are needed.
+func Power(hand []cards.Card, community []cards.Card) (best uint32, power uint64) {
+	rankBits, suitBits := common.PickAll(hand, common.HighRanks)
+	power = common.Std52HighPower(rankBits, suitBits != 0)
+	if power == wheel {
+		power = aceFive
+	} else if power == steelWheel {
+		power = 5<<39 | aceFive
+	}
+	best = 0b11111
+	return
+}
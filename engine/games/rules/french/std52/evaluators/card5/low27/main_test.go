@@ -0,0 +1,46 @@
+package low27
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	. "github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"testing"
+)
+
+func testHandPower(t *testing.T, expectedPower uint64, cards ...cards.Card) {
+	_, power := Power(cards, nil)
+	if power != expectedPower {
+		t.Errorf("Testing hand: %v\nexpected power: %#064b\n     got power: %#064b\n", cards, expectedPower, power)
+	}
+}
+
+func power(cards ...cards.Card) uint64 {
+	_, result := Power(cards, nil)
+	return result
+}
+
+func TestHandPowers(t *testing.T) {
+	// The wheel is not a straight, but an Ace-high bust.
+	testHandPower(t, 0b0000000000000000000000000000001000000001111, SA, C2, H3, D4, S5)
+	// And, suited, it is just a flush.
+	testHandPower(t, 0b0101000000000000000000000000001000000001111, SA, S2, S3, S4, S5)
+	// Other straights still count.
+	testHandPower(t, 0b0100000000000000000000000000000000000010000, S6, C2, H3, D4, S5)
+	// Bust.
+	testHandPower(t, 0b0000000000000000000000000000000000000101111, S7, C2, H3, D4, S5)
+}
+
+func TestHandOrder(t *testing.T) {
+	best := power(S7, C5, H4, D3, S2)
+	if other := power(S8, C5, H4, D3, S2); other <= best {
+		t.Errorf("expected 8-5-4-3-2 to be worse than 7-5-4-3-2")
+	}
+	if other := power(SA, C5, H4, D3, S2); other <= best {
+		t.Errorf("expected A-5-4-3-2 to be worse than 7-5-4-3-2")
+	}
+	if other := power(S6, C5, H4, D3, S2); other <= power(SK, CQ, HJ, DT, S8) {
+		t.Errorf("expected 6-5-4-3-2 (a straight) to be worse than a King-high bust")
+	}
+	if other := power(S7, S5, S4, S3, S2); other <= power(CA, HK, DQ, SJ, S9) {
+		t.Errorf("expected a flush to be worse than an Ace-high bust")
+	}
+}
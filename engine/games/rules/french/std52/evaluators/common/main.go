@@ -306,3 +306,34 @@ func PickAll(hand []cards.Card, modifiedRanks []uint64) (handBits uint64, suitBi
 	}
 	return
 }
+
+// The order of the suits (clubs, hearts, diamonds, spades)
+// when they are used to break ties between cards of the
+// same rank: clubs are the lowest, then diamonds, then
+// hearts, and spades are the highest.
+var SuitOrders = []int{0, 2, 1, 3}
+
+// Gives a value to a single card, considering its rank
+// (Ace high) and then its suit to break ties. This value
+// is useful when a single card decides something (e.g.
+// the bring-in in stud games, or the odd chip of a pot).
+func CardValue(card cards.Card) int {
+	frenchCard := card.(french.Card)
+	return Ranks[frenchCard]*4 + SuitOrders[frenchCard/13]
+}
+
+// Gives a value to a single card like CardValue does, but
+// with the Ace counting low (e.g. for the bring-in in Razz,
+// which is posted by the highest card).
+func LowCardValue(card cards.Card) int {
+	frenchCard := card.(french.Card)
+	return (Ranks[frenchCard]+1)%13*4 + SuitOrders[frenchCard/13]
+}
+
+// Tells whether a lowball power (as computed by the
+// Std52LowballPower function) qualifies as an "eight
+// or better" low: five unpaired cards, being 8 or
+// lower, as required in hi/lo games.
+func EightOrBetter(power uint64) bool {
+	return power < 1<<8
+}
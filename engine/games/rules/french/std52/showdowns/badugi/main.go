@@ -1,3 +1,20 @@
 package badugi
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/badugi"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/drawing"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// Badugi: four cards, three draws, and the best low hand
+// made of cards of different ranks and suits wins. Hands
+// of fewer cards lose to hands of more cards, and only the
+// cards making the hand are shown.
+var Game = drawing.Game{Cards: 4, Draws: 3, Low: badugi.Power, ShowBest: true}
+
+// Plays a whole hand of Badugi.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
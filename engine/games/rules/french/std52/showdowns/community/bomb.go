@@ -0,0 +1,95 @@
+package community
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/deck"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+var ErrDoubleBoardHiLo = errors.New("hi/lo games cannot be played with two boards")
+
+// Bomb pots are played once every some hands (e.g. in home
+// games): every seat dealt in posts the same ante, and the
+// play starts on the flop, optionally with two boards.
+type Bombs struct {
+	// One hand out of this many is a bomb pot (0 means
+	// no bomb pots).
+	Every       int
+	Ante        uint64
+	DoubleBoard bool
+	played      int
+}
+
+// Tells whether the next hand is a bomb pot, counting it.
+func (bombs *Bombs) next() bool {
+	if bombs.Every <= 0 {
+		return false
+	}
+	bombs.played++
+	if bombs.played < bombs.Every {
+		return false
+	}
+	bombs.played = 0
+	return true
+}
+
+// Tells whether the bomb pots can be played in the given
+// game: hi/lo games cannot be played with two boards.
+func (bombs *Bombs) Validate(game Game) error {
+	if bombs.DoubleBoard && game.hiLo() {
+		return ErrDoubleBoardHiLo
+	}
+	return nil
+}
+
+// Plays a whole hand of the game: a bomb pot, when it is
+// time for it, or a regular hand with the given blinds.
+func (bombs *Bombs) Play(game Game, hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	if bombs.next() {
+		game.PlayBomb(hand, positions, bombs.Ante, bombs.DoubleBoard)
+	} else {
+		game.Play(hand, positions, blinds)
+	}
+}
+
+// Plays a whole bomb pot of this game. Every seat dealt in
+// posts the ante, the hole cards are dealt, and the first
+// betting round is played after the flop. In double-board
+// pots, each street is dealt to both boards, and each half
+// of the pots is awarded to the best hands in one board.
+// Hi/lo games are always played with a single board.
+func (game Game) PlayBomb(hand *hands.Hand, positions *button.Hand, ante uint64, doubleBoard bool) {
+	doubleBoard = doubleBoard && !game.hiLo()
+	hand.Start(deck.Deck)
+	if positions.Button != nil {
+		hand.Button = positions.Button.SeatID()
+	} else {
+		hand.Button = 0
+	}
+	if doubleBoard {
+		hand.StartDoubleBoard()
+	}
+	hand.Pots = forced.PostAntes(hand.GameID, hand.TableID, hand.Seats, ante, nil, hand.Broadcaster)
+	hand.DealAround(make([]bool, game.HoleCards)...)
+	game.discard(hand, 0)
+	for index, count := range Streets {
+		if !hand.Contested() || (game.canRunOut(uint8(index+1)) && hand.RunOut(Streets[index:])) {
+			break
+		}
+		if doubleBoard {
+			hand.DealBoards(count)
+		} else {
+			hand.DealCommunity(count)
+		}
+		hand.Bet(uint8(index+1), hand.OrderFrom(0))
+		game.discard(hand, uint8(index+1))
+	}
+	if hand.Contested() {
+		hand.Showdown(hands.Evaluation{High: game.High, Low: game.Low, Qualifies: game.Qualifies})
+	} else {
+		hand.AwardUncontested()
+	}
+	hand.Finish()
+}
@@ -0,0 +1,94 @@
+package community
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/deck"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// The community cards dealt in each street after the
+// pre-flop: the flop, the turn and the river.
+var Streets = []int{3, 1, 1}
+
+// A community cards game (e.g. Hold'Em or Omaha) is
+// defined by the number of hole cards and the way the
+// hands are evaluated. Hi/lo games have an evaluator
+// for the low hands, and a rule telling whether a low
+// power qualifies (e.g. eight or better). Some games
+// (e.g. Pineapple) make each seat discard some of its
+// hole cards after the betting round of a street (0
+// being the pre-flop).
+type Game struct {
+	HoleCards     int
+	High          hands.Evaluator
+	Low           hands.Evaluator
+	Qualifies     func(power uint64) bool
+	Discards      int
+	DiscardStreet uint8
+}
+
+// Runs the discard round after the betting round of the
+// given street, if this game has one there.
+func (game Game) discard(hand *hands.Hand, street uint8) {
+	if game.Discards > 0 && street == game.DiscardStreet && hand.Contested() {
+		hand.Discard(hand.OrderFrom(0), game.Discards)
+	}
+}
+
+// Tells whether the pots are split between the high and
+// the low hands.
+func (game Game) hiLo() bool {
+	return game.High != nil && game.Low != nil
+}
+
+// Tells whether the rest of the board may be run more than
+// once before dealing the given street: not until the seats
+// discarded, if they must.
+func (game Game) canRunOut(street uint8) bool {
+	return game.Discards == 0 || street > game.DiscardStreet
+}
+
+// Plays a whole hand of this game. The hand context must
+// have the seats dealt in (as decided by the button manager,
+// in the given positions) and the betting structure. The
+// forced bets (and the straddle, if any) are posted, the
+// hole cards are dealt to each seat, and the betting rounds
+// are played before the flop and after each street (the
+// seats discarding after one of them, if they must, before
+// their hands may be tabled). When
+// no more betting is possible, the seats may agree to run
+// the rest of the board more than once. If more than one
+// seat remains at the end, the hands are shown and the pots
+// are awarded to the best ones.
+func (game Game) Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	hand.Start(deck.Deck)
+	hand.Discarding = game.Discards > 0
+	if positions.Button != nil {
+		hand.Button = positions.Button.SeatID()
+	} else {
+		hand.Button = 0
+	}
+	hand.Pots = blinds.Post(hand.GameID, hand.TableID, positions, hand.Broadcaster)
+	last := positions.Big
+	if straddler := blinds.PostStraddle(hand.GameID, hand.TableID, positions, hand.Broadcaster); straddler != nil {
+		last = straddler
+	}
+	hand.DealAround(make([]bool, game.HoleCards)...)
+	hand.Bet(0, hand.OrderAfter(last))
+	game.discard(hand, 0)
+	for index, count := range Streets {
+		if !hand.Contested() || (game.canRunOut(uint8(index+1)) && hand.RunOut(Streets[index:])) {
+			break
+		}
+		hand.DealCommunity(count)
+		hand.Bet(uint8(index+1), hand.OrderFrom(0))
+		game.discard(hand, uint8(index+1))
+	}
+	if hand.Contested() {
+		hand.Showdown(hands.Evaluation{High: game.High, Low: game.Low, Qualifies: game.Qualifies})
+	} else {
+		hand.AwardUncontested()
+	}
+	hand.Finish()
+}
@@ -0,0 +1,324 @@
+package community
+
+import (
+	"fmt"
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	card7 "github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/omaha/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/omaha/low"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"reflect"
+	"testing"
+)
+
+// Keeps the showdowns, by mode.
+type showdownsRecorder map[showdowns.Mode]bool
+
+func (recorder showdownsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if showdown, ok := content.(tables.Showdown); ok {
+		recorder[showdown.Mode] = !showdown.Skipped
+	}
+}
+
+// Stacks the given cards on top of a fresh std52 deck,
+// so they are dealt in the given order.
+type stacked []french.Card
+
+func (top stacked) Shuffle(deck cards.Deck) {
+	positions := map[french.Card]int{}
+	cardsAt := map[int]french.Card{}
+	for index := 0; index < deck.Len(); index++ {
+		positions[french.Card(index)] = index
+		cardsAt[index] = french.Card(index)
+	}
+	for offset, card := range top {
+		from, to := positions[card], deck.Len()-1-offset
+		deck.Swap(from, to)
+		other := cardsAt[to]
+		positions[card], positions[other] = to, from
+		cardsAt[to], cardsAt[from] = card, other
+	}
+}
+
+// The second seat (small blind) has the best low, and
+// the third seat (big blind) has a straight.
+var stackedDeck = stacked{
+	french.CA, french.C3, french.SK,
+	french.C2, french.C4, french.CK,
+	french.HK, french.HQ, french.H9,
+	french.DK, french.DQ, french.D9,
+	french.C8, french.H5, french.D6, french.S7,
+	french.D8, french.CJ,
+	french.H8, french.CT,
+}
+
+func play(t *testing.T, game Game) ([]seats.Seat, showdownsRecorder) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&seatstest.Player{}, 1000)
+		s[index] = seat
+	}
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	recorder := showdownsRecorder{}
+	hand := &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       positions.DealtIn,
+		Broadcaster: environment.NewBroadcaster(s, recorder),
+		Shuffler:    stackedDeck,
+		Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+			if options.CanCheck {
+				return hands.Decision{Action: actions.Check}
+			}
+			return hands.Decision{Action: actions.Call}
+		}),
+		Structure: structures.PotLimit{BigBlind: 10},
+	}
+	game.Play(hand, positions, forced.Blinds{Small: 5, Big: 10})
+	return s, recorder
+}
+
+func testStacks(t *testing.T, s []seats.Seat, stacks ...uint64) {
+	for index, stack := range stacks {
+		if s[index].Stack() != stack {
+			t.Errorf("expected seat %d to have %d chips, got %d", index+1, stack, s[index].Stack())
+		}
+	}
+}
+
+func TestHighOnly(t *testing.T) {
+	s, recorder := play(t, Game{HoleCards: 4, High: high.Power})
+	testStacks(t, s, 990, 990, 1020)
+	if len(recorder) != 1 || !recorder[showdowns.Standard] {
+		t.Errorf("expected only the standard showdown: %v", recorder)
+	}
+}
+
+func TestHighLow(t *testing.T) {
+	s, recorder := play(t, Game{HoleCards: 4, High: high.Power, Low: low.Power, Qualifies: common.EightOrBetter})
+	testStacks(t, s, 990, 1005, 1005)
+	if len(recorder) != 2 || !recorder[showdowns.High] || !recorder[showdowns.Low] {
+		t.Errorf("expected both the high and low showdowns: %v", recorder)
+	}
+}
+
+func TestHighLowWithoutQualifiedLow(t *testing.T) {
+	s, recorder := play(t, Game{HoleCards: 4, High: high.Power, Low: low.Power, Qualifies: func(power uint64) bool {
+		return false
+	}})
+	testStacks(t, s, 990, 990, 1020)
+	if len(recorder) != 2 || !recorder[showdowns.High] || recorder[showdowns.Low] {
+		t.Errorf("expected the low showdown to be skipped: %v", recorder)
+	}
+}
+
+// Keeps the boards of the community cards dealt.
+type boardsRecorder []uint8
+
+func (recorder *boardsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if dealt, ok := content.(tables.CommunityCardsHaveBeenDealt); ok {
+		*recorder = append(*recorder, dealt.Board)
+	}
+}
+
+func TestBombs(t *testing.T) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&seatstest.Player{}, 1000)
+		s[index] = seat
+	}
+	manager := button.NewManager(button.DeadButton, s)
+	bombs := &Bombs{Every: 2, Ante: 20, DoubleBoard: true}
+	for index := 0; index < 2; index++ {
+		positions, err := manager.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var recorder boardsRecorder
+		asked, bets := 0, 0
+		hand := &hands.Hand{
+			GameID:      1,
+			TableID:     1,
+			HandID:      uint64(index + 1),
+			Seats:       positions.DealtIn,
+			Broadcaster: environment.NewBroadcaster(s, &recorder),
+			Shuffler:    stacked{},
+			Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+				asked++
+				if options.CanCheck {
+					return hands.Decision{Action: actions.Check}
+				}
+				bets++
+				return hands.Decision{Action: actions.Call}
+			}),
+			Structure: structures.PotLimit{BigBlind: 10},
+		}
+		bombs.Play(Game{HoleCards: 4, High: high.Power}, hand, positions, forced.Blinds{Small: 5, Big: 10})
+		if index == 0 {
+			if len(recorder) != 3 || bets == 0 {
+				t.Errorf("expected a regular hand first: %v", recorder)
+			}
+		} else if len(recorder) != 6 || recorder[0] != 0 || recorder[1] != 1 || asked != 9 || bets != 0 {
+			t.Errorf("expected a double-board bomb pot, starting on the flop: %v, %d asked", recorder, asked)
+		}
+	}
+	total := uint64(0)
+	for _, seat := range s {
+		total += seat.Stack()
+	}
+	if total != 3000 {
+		t.Errorf("expected the chips to be kept among the seats, got %d", total)
+	}
+}
+
+func TestDoubleBoardHiLo(t *testing.T) {
+	game := Game{HoleCards: 4, High: high.Power, Low: low.Power, Qualifies: common.EightOrBetter}
+	bombs := &Bombs{Every: 1, Ante: 20, DoubleBoard: true}
+	if err := bombs.Validate(game); err != ErrDoubleBoardHiLo {
+		t.Errorf("expected ErrDoubleBoardHiLo, got %v", err)
+	}
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&seatstest.Player{}, 1000)
+		s[index] = seat
+	}
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var recorder boardsRecorder
+	hand := &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       positions.DealtIn,
+		Broadcaster: environment.NewBroadcaster(s, &recorder),
+		Shuffler:    stackedDeck,
+		Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+			return hands.Decision{Action: actions.Check}
+		}),
+		Structure: structures.PotLimit{BigBlind: 10},
+	}
+	bombs.Play(game, hand, positions, forced.Blinds{Small: 5, Big: 10})
+	// The bomb pot is played with a single board, and no
+	// chips are lost.
+	total := uint64(0)
+	for _, seat := range s {
+		total += seat.Stack()
+	}
+	if len(recorder) != 3 || total != 3000 {
+		t.Errorf("expected a single-board bomb pot keeping the chips, got %v and %d chips", recorder, total)
+	}
+}
+
+func TestPineapple(t *testing.T) {
+	for street, flop := range []int{0, 3} {
+		s := make([]seats.Seat, 3)
+		for index := range s {
+			seat := seats.NewBaseSeat(uint8(index + 1))
+			seat.Sit(&seatstest.Player{}, 1000)
+			s[index] = seat
+		}
+		positions, err := button.NewManager(button.DeadButton, s).Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var community []int
+		hand := &hands.Hand{
+			GameID:      1,
+			TableID:     1,
+			HandID:      1,
+			Seats:       positions.DealtIn,
+			Broadcaster: environment.NewBroadcaster(s, showdownsRecorder{}),
+			Shuffler:    stacked{},
+			Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+				if options.CanCheck {
+					return hands.Decision{Action: actions.Check}
+				}
+				return hands.Decision{Action: actions.Call}
+			}),
+			Discards: hands.DiscardInputFunc(func(hand *hands.Hand, seat seats.Seat, max int) hands.Discard {
+				community = append(community, len(hand.Community))
+				if len(seat.Cards(true)) != 3 || max != 1 {
+					t.Errorf("expected to discard one of three hole cards")
+				}
+				return hands.Discard{Indices: []int{0}}
+			}),
+			Structure: structures.PotLimit{BigBlind: 10},
+		}
+		Game{HoleCards: 3, High: card7.Power, Discards: 1, DiscardStreet: uint8(street)}.Play(hand, positions,
+			forced.Blinds{Small: 5, Big: 10})
+		if len(community) != 3 || community[0] != flop || community[2] != flop {
+			t.Errorf("expected each seat to discard after street %d: %v", street, community)
+		}
+		if len(hand.Muck) != 3+3+6 {
+			t.Errorf("expected the discards, the burnt cards and the hands in the muck, got %d", len(hand.Muck))
+		}
+	}
+}
+
+// Keeps the hole cards shown, and the discards, in order.
+type discardsRecorder []string
+
+func (recorder *discardsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		switch seatContent := seatMessage.Content.(type) {
+		case messages.SeatGaveCards:
+			*recorder = append(*recorder, "gave")
+		case messages.SeatHasShownCards:
+			*recorder = append(*recorder, fmt.Sprintf("shown %d", len(seatContent.Cards)))
+		}
+	}
+}
+
+func TestPineappleAllInBeforeDiscarding(t *testing.T) {
+	// Everybody is all-in before the flop: the hands are
+	// tabled only after the discards.
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var recorder discardsRecorder
+	hand := &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       positions.DealtIn,
+		Broadcaster: environment.NewBroadcaster(s, &recorder),
+		Shuffler:    stacked{},
+		Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+			return hands.Decision{Action: actions.AllIn}
+		}),
+		Structure: structures.NoLimit{BigBlind: 10},
+	}
+	Game{HoleCards: 3, High: card7.Power, Discards: 1}.Play(hand, positions, forced.Blinds{Small: 5, Big: 10})
+	expected := []string{"gave", "gave", "gave", "shown 2", "shown 2", "shown 2"}
+	if !reflect.DeepEqual([]string(recorder), expected) {
+		t.Errorf("expected the hands to be tabled after the discards: %v", recorder)
+	}
+}
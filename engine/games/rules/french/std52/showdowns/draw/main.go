@@ -1,4 +1,18 @@
 package draw
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card5/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/drawing"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Five-card draw: five cards, a single draw, and the
+// best high hand wins.
+var Game = drawing.Game{Cards: 5, Draws: 1, High: high.Power}
+
+// Plays a whole hand of Five-card draw.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
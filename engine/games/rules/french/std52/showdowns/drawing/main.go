@@ -0,0 +1,63 @@
+package drawing
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/deck"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// A draw game (e.g. Five-card draw or 2-7 Triple draw) is
+// defined by the number of cards in the hand, the number
+// of draws, and the way the hands are evaluated. Lowball
+// games only have an evaluator for the low hands, while
+// hi/lo games have both, and a rule telling whether a low
+// power qualifies. Games with a single evaluator may show
+// only the cards making the best hand (e.g. Badugi, where
+// the best subset is the official hand).
+type Game struct {
+	Cards     int
+	Draws     int
+	High      hands.Evaluator
+	Low       hands.Evaluator
+	Qualifies func(power uint64) bool
+	ShowBest  bool
+}
+
+// Plays a whole hand of this game. The hand context must
+// have the seats dealt in (as decided by the button manager,
+// in the given positions), the betting structure and the
+// discard input. The forced bets (and the straddle, if any)
+// are posted, the cards are dealt to each seat, and the
+// betting rounds are played before the first draw and after
+// each draw, where each seat replaces any of its cards or
+// stands pat. If more than one seat remains at the end, the
+// hands are shown and the pots are awarded to the best ones.
+func (game Game) Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	hand.Start(deck.Deck)
+	if positions.Button != nil {
+		hand.Button = positions.Button.SeatID()
+	} else {
+		hand.Button = 0
+	}
+	hand.Pots = blinds.Post(hand.GameID, hand.TableID, positions, hand.Broadcaster)
+	last := positions.Big
+	if straddler := blinds.PostStraddle(hand.GameID, hand.TableID, positions, hand.Broadcaster); straddler != nil {
+		last = straddler
+	}
+	hand.DealAround(make([]bool, game.Cards)...)
+	hand.Bet(0, hand.OrderAfter(last))
+	for draw := 1; draw <= game.Draws; draw++ {
+		if !hand.Contested() {
+			break
+		}
+		hand.Draw(hand.OrderFrom(0), game.Cards)
+		hand.Bet(uint8(draw), hand.OrderFrom(0))
+	}
+	if hand.Contested() {
+		hand.Showdown(hands.Evaluation{High: game.High, Low: game.Low, Qualifies: game.Qualifies, BestOnly: game.ShowBest})
+	} else {
+		hand.AwardUncontested()
+	}
+	hand.Finish()
+}
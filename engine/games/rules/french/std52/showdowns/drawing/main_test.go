@@ -0,0 +1,210 @@
+package drawing
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/badugi"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card5/low27"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/shufflers"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"github.com/luismasuelli/poker-go/engine/misc"
+	"testing"
+)
+
+// Counts the discards (and rejections) told to the owner.
+type dummyPlayer struct {
+	seatstest.Player
+	gave     int
+	rejected int
+}
+
+func (player *dummyPlayer) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		switch seatMessage.Content.(type) {
+		case messages.YouGaveCards:
+			player.gave++
+		case messages.YourActionHasBeenRejected:
+			player.rejected++
+		}
+	}
+}
+
+// Keeps the counts of the cards given and drawn, as seen
+// by the whole table.
+type drawsRecorder struct {
+	gave       []int
+	drawn      int
+	revealed   int
+	indexTells int
+}
+
+func (recorder *drawsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		switch seatContent := seatMessage.Content.(type) {
+		case messages.SeatGaveCards:
+			recorder.gave = append(recorder.gave, seatContent.Count)
+		case messages.SeatDrewCards:
+			for _, card := range seatContent.Cards {
+				recorder.drawn++
+				if card != nil {
+					recorder.revealed++
+				}
+			}
+		case messages.YouGaveCards:
+			recorder.indexTells++
+		}
+	}
+}
+
+// Keeps the deck as it is: the cards are dealt from
+// the top (i.e. from the aces of spades, downwards).
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(deck cards.Deck) {}
+
+// Stacks the given cards on top of a fresh std52 deck,
+// so they are dealt in the given order.
+type stacked []french.Card
+
+func (top stacked) Shuffle(deck cards.Deck) {
+	positions := map[french.Card]int{}
+	cardsAt := map[int]french.Card{}
+	for index := 0; index < deck.Len(); index++ {
+		positions[french.Card(index)] = index
+		cardsAt[index] = french.Card(index)
+	}
+	for offset, card := range top {
+		from, to := positions[card], deck.Len()-1-offset
+		deck.Swap(from, to)
+		other := cardsAt[to]
+		positions[card], positions[other] = to, from
+		cardsAt[to], cardsAt[from] = card, other
+	}
+}
+
+// Creates a hand among three seats, where every player
+// checks or calls.
+func makeHand(t *testing.T, shuffler shufflers.Shuffler, notifiable misc.Notifiable) ([]seats.Seat,
+	*button.Hand, *hands.Hand) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&dummyPlayer{}, 1000)
+		s[index] = seat
+	}
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return s, positions, &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       positions.DealtIn,
+		Broadcaster: environment.NewBroadcaster(s, notifiable),
+		Shuffler:    shuffler,
+		Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+			if options.CanCheck {
+				return hands.Decision{Action: actions.Check}
+			}
+			return hands.Decision{Action: actions.Call}
+		}),
+		Structure: structures.FixedLimit{SmallBet: 10, BigBet: 20, BigStreet: 2},
+	}
+}
+
+func testStacks(t *testing.T, s []seats.Seat, stacks ...uint64) {
+	for index, stack := range stacks {
+		if s[index].Stack() != stack {
+			t.Errorf("expected seat %d to have %d chips, got %d", index+1, stack, s[index].Stack())
+		}
+	}
+}
+
+func TestTripleDraw(t *testing.T) {
+	recorder := &drawsRecorder{}
+	s, positions, hand := makeHand(t, unshuffled{}, recorder)
+	mistaken := false
+	hand.Discards = hands.DiscardInputFunc(func(hand *hands.Hand, seat seats.Seat, max int) hands.Discard {
+		if !mistaken {
+			mistaken = true
+			return hands.Discard{RequestID: 1, Indices: []int{max}}
+		}
+		return hands.Discard{Indices: []int{0}}
+	})
+	Game{Cards: 5, Draws: 3, Low: low27.Power}.Play(hand, positions, forced.Blinds{Small: 5, Big: 10})
+	// Each seat replaces its first card in each draw. The
+	// second seat ends with Q-9-6-5-2, the first one with
+	// K-T-7-4-3 and the third one with a diamonds flush.
+	testStacks(t, s, 990, 1020, 990)
+	if len(recorder.gave) != 9 {
+		t.Errorf("expected 9 discards, got %v", recorder.gave)
+	}
+	for _, count := range recorder.gave {
+		if count != 1 {
+			t.Errorf("expected each discard to be of 1 card, got %v", recorder.gave)
+			break
+		}
+	}
+	if recorder.drawn != 24 || recorder.revealed != 0 || recorder.indexTells != 0 {
+		t.Errorf("expected only the counts of 24 hidden cards to be told to the table: %+v", recorder)
+	}
+	if player := positions.DealtIn[0].Player().(*dummyPlayer); player.gave != 3 || player.rejected != 1 {
+		t.Errorf("expected the first seat to be told about 3 discards and 1 rejection: %+v", player)
+	}
+}
+
+// Keeps the cards shown by each seat.
+type shownRecorder map[uint8][]cards.Card
+
+func (recorder shownRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		if shown, ok := seatMessage.Content.(messages.SeatHasShownCards); ok {
+			recorder[seatMessage.SeatID] = shown.Cards
+		}
+	}
+}
+
+func TestShowBest(t *testing.T) {
+	// Everybody stands pat: the first seat dealt in has a
+	// four-card badugi (A-2-3-4), the second one a three
+	// card badugi (4-3-2) and the third one a two-card
+	// badugi (Q-K).
+	recorder := shownRecorder{}
+	_, positions, hand := makeHand(t, stacked{
+		french.CA, french.C2, french.CK,
+		french.H2, french.H3, french.HK,
+		french.D3, french.D4, french.HQ,
+		french.S4, french.D5, french.SQ,
+	}, recorder)
+	Game{Cards: 4, Draws: 3, Low: badugi.Power, ShowBest: true}.Play(hand, positions, forced.Blinds{Small: 5, Big: 10})
+	for index, count := range []int{4, 3, 2} {
+		seatID := positions.DealtIn[index].SeatID()
+		shown := 0
+		for _, card := range recorder[seatID] {
+			if card != nil {
+				shown++
+			}
+		}
+		if len(recorder[seatID]) != 4 || shown != count {
+			t.Errorf("expected seat %d to show %d of its 4 cards: %v", seatID, count, recorder[seatID])
+		}
+	}
+	if winner := positions.DealtIn[0]; winner.Stack() != 1020 {
+		t.Errorf("expected seat %d to win the pot", winner.SeatID())
+	}
+}
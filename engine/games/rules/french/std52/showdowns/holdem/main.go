@@ -1,3 +1,18 @@
 package holdem
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/community"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// Texas Hold'Em: two hole cards, and the best high
+// hand out of any five among them and the board.
+var Game = community.Game{HoleCards: 2, High: high.Power}
+
+// Plays a whole hand of Texas Hold'Em.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
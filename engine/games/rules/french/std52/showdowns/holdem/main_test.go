@@ -0,0 +1,147 @@
+package holdem
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"testing"
+)
+
+type dummyPlayer struct {
+	seatstest.Player
+	drawn int
+}
+
+func (player *dummyPlayer) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		if drew, ok := seatMessage.Content.(messages.YouDrewCards); ok {
+			player.drawn += len(drew.Cards)
+		}
+	}
+}
+
+// Counts the table messages, by type.
+type messagesCounter map[string]int
+
+func (counter messagesCounter) Notify(message interface{}) {
+	switch content := message.(games.GameMessage).Content.(tables.TableMessage).Content.(type) {
+	case tables.CommunityCardsHaveBeenDealt:
+		counter["community"]++
+	case tables.Showdown:
+		counter["showdown"]++
+	case messages.SeatMessage:
+		switch content.Content.(type) {
+		case messages.SeatDrewCards:
+			counter["drew"]++
+		case messages.SeatHasShownCards:
+			counter["shown"]++
+		case messages.PlayerWonChips:
+			counter["won"]++
+		}
+	}
+}
+
+// Keeps the deck as it is: the cards are dealt from
+// the top (i.e. from the aces of spades, downwards).
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(deck cards.Deck) {}
+
+func play(t *testing.T, input hands.InputFunc) ([]seats.Seat, messagesCounter) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&dummyPlayer{}, 1000)
+		s[index] = seat
+	}
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	counter := messagesCounter{}
+	hand := &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       positions.DealtIn,
+		Broadcaster: environment.NewBroadcaster(s, counter),
+		Shuffler:    unshuffled{},
+		Input:       input,
+		Structure:   structures.NoLimit{BigBlind: 10},
+	}
+	Play(hand, positions, forced.Blinds{Small: 5, Big: 10})
+	for _, seat := range s {
+		if len(seat.Cards(true)) != 0 || seat.Status() != seats.Waiting || seat.Pot() != 0 {
+			t.Errorf("expected seat %d to be cleared after the hand", seat.SeatID())
+		}
+	}
+	return s, counter
+}
+
+func testStacks(t *testing.T, s []seats.Seat, stacks ...uint64) {
+	for index, stack := range stacks {
+		if s[index].Stack() != stack {
+			t.Errorf("expected seat %d to have %d chips, got %d", index+1, stack, s[index].Stack())
+		}
+	}
+}
+
+func TestFoldToBigBlind(t *testing.T) {
+	s, counter := play(t, func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+		return hands.Decision{Action: actions.Fold}
+	})
+	testStacks(t, s, 1000, 995, 1005)
+	if counter["community"] != 0 || counter["showdown"] != 0 || counter["drew"] != 6 || counter["won"] != 1 {
+		t.Errorf("unexpected messages: %v", counter)
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	s, counter := play(t, func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+		if options.CanCheck {
+			return hands.Decision{Action: actions.Check}
+		}
+		return hands.Decision{Action: actions.Call}
+	})
+	// The small blind gets As Js, and makes the best
+	// flush with the 7s 6s 5s 3s board.
+	testStacks(t, s, 990, 1020, 990)
+	if counter["community"] != 3 || counter["showdown"] != 1 || counter["shown"] != 3 || counter["won"] != 1 {
+		t.Errorf("unexpected messages: %v", counter)
+	}
+	for _, seat := range s {
+		if drawn := seat.Player().(*dummyPlayer).drawn; drawn != 2 {
+			t.Errorf("expected seat %d to be told about 2 cards, got %d", seat.SeatID(), drawn)
+		}
+	}
+}
+
+func TestAllInRunout(t *testing.T) {
+	raised := false
+	s, counter := play(t, func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+		if !raised {
+			raised = true
+			return hands.Decision{Action: actions.AllIn}
+		} else if options.ToCall != 0 {
+			return hands.Decision{Action: actions.Call}
+		}
+		return hands.Decision{Action: actions.Check}
+	})
+	// The button shoves, and both blinds call: the small
+	// blind wins everything with the best flush.
+	testStacks(t, s, 0, 3000, 0)
+	if counter["community"] != 3 || counter["showdown"] != 1 {
+		t.Errorf("unexpected messages: %v", counter)
+	}
+}
@@ -0,0 +1,132 @@
+package mixed
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/holdem"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/omaha"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/omaha_hilo"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/razz"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/stud7"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/stud7_hilo"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/triple_draw27"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/rotation"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// The stakes of a mixed games table. The limit games are
+// played with the small and big bets (and blinds of half
+// the small bet and the small bet), the stud games with
+// the ante and the bring-in (and the same bets), and the
+// big bet games (no-limit and pot-limit) with the blinds.
+type Stakes struct {
+	SmallBet   uint64
+	BigBet     uint64
+	Ante       uint64
+	BringIn    uint64
+	SmallBlind uint64
+	BigBlind   uint64
+}
+
+// The fixed-limit structure of the limit games: the big
+// bet starts in the turn (flop games), the second draw
+// (draw games) or the fifth street (stud games), and the
+// raises are capped to a bet and three raises.
+func (stakes Stakes) limit() structures.FixedLimit {
+	return structures.FixedLimit{SmallBet: stakes.SmallBet, BigBet: stakes.BigBet, BigStreet: 2, Cap: 4}
+}
+
+// The blinds of the limit games.
+func (stakes Stakes) limitBlinds() forced.Blinds {
+	return forced.Blinds{Small: stakes.SmallBet / 2, Big: stakes.SmallBet}
+}
+
+// The forced bets of the stud games.
+func (stakes Stakes) stud() forced.Stud {
+	return forced.Stud{Ante: stakes.Ante, BringIn: stakes.BringIn}
+}
+
+// The blinds of the big bet games.
+func (stakes Stakes) bigBlinds() forced.Blinds {
+	return forced.Blinds{Small: stakes.SmallBlind, Big: stakes.BigBlind}
+}
+
+// Limit Texas Hold'Em.
+func (stakes Stakes) LimitHoldem() rotation.Variant {
+	return rotation.Variant{Name: "Limit Hold'em", Structure: stakes.limit(), Button: true,
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			holdem.Play(hand, positions, stakes.limitBlinds())
+		}}
+}
+
+// Limit Omaha Hi/Lo.
+func (stakes Stakes) LimitOmahaHiLo() rotation.Variant {
+	return rotation.Variant{Name: "Omaha Hi/Lo", Structure: stakes.limit(), Button: true,
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			omaha_hilo.Play(hand, positions, stakes.limitBlinds())
+		}}
+}
+
+// Razz.
+func (stakes Stakes) Razz() rotation.Variant {
+	return rotation.Variant{Name: "Razz", Structure: stakes.limit(),
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			razz.Play(hand, stakes.stud())
+		}}
+}
+
+// Seven-card stud.
+func (stakes Stakes) Stud() rotation.Variant {
+	return rotation.Variant{Name: "Seven-card Stud", Structure: stakes.limit(),
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			stud7.Play(hand, stakes.stud())
+		}}
+}
+
+// Seven-card stud Hi/Lo.
+func (stakes Stakes) StudHiLo() rotation.Variant {
+	return rotation.Variant{Name: "Seven-card Stud Hi/Lo", Structure: stakes.limit(),
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			stud7_hilo.Play(hand, stakes.stud())
+		}}
+}
+
+// Limit 2-7 Triple draw.
+func (stakes Stakes) TripleDraw27() rotation.Variant {
+	return rotation.Variant{Name: "2-7 Triple Draw", Structure: stakes.limit(), Button: true,
+		Play: func(hand *hands.Hand, positions *button.Hand) {
+			triple_draw27.Play(hand, positions, stakes.limitBlinds())
+		}}
+}
+
+// No-limit Texas Hold'Em.
+func (stakes Stakes) NoLimitHoldem() rotation.Variant {
+	return rotation.Variant{Name: "No-Limit Hold'em", Structure: structures.NoLimit{BigBlind: stakes.BigBlind},
+		Button: true, Play: func(hand *hands.Hand, positions *button.Hand) {
+			holdem.Play(hand, positions, stakes.bigBlinds())
+		}}
+}
+
+// Pot-limit Omaha.
+func (stakes Stakes) PotLimitOmaha() rotation.Variant {
+	return rotation.Variant{Name: "Pot-Limit Omaha", Structure: structures.PotLimit{BigBlind: stakes.BigBlind},
+		Button: true, Play: func(hand *hands.Hand, positions *button.Hand) {
+			omaha.Play(hand, positions, stakes.bigBlinds())
+		}}
+}
+
+// HORSE: Hold'Em, Omaha Hi/Lo, Razz, Stud and Stud Hi/Lo
+// (Eight or better), all of them in fixed-limit.
+func (stakes Stakes) HORSE() []rotation.Variant {
+	return []rotation.Variant{
+		stakes.LimitHoldem(), stakes.LimitOmahaHiLo(), stakes.Razz(), stakes.Stud(), stakes.StudHiLo(),
+	}
+}
+
+// 8-Game: 2-7 Triple draw and the HORSE games in fixed-
+// limit, and then No-limit Hold'Em and Pot-limit Omaha.
+func (stakes Stakes) EightGame() []rotation.Variant {
+	return append(append([]rotation.Variant{stakes.TripleDraw27()}, stakes.HORSE()...),
+		stakes.NoLimitHoldem(), stakes.PotLimitOmaha())
+}
@@ -0,0 +1,80 @@
+package mixed
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/rotation"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"testing"
+)
+
+// Keeps the names of the announced variants.
+type announcements []string
+
+func (recorder *announcements) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if announced, ok := content.(tables.NextVariantHasBeenAnnounced); ok {
+		*recorder = append(*recorder, announced.Name)
+	}
+}
+
+// Keeps the deck as it is.
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(deck cards.Deck) {}
+
+func TestEightGame(t *testing.T) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&seatstest.Player{}, 1000)
+		s[index] = seat
+	}
+	stakes := Stakes{SmallBet: 10, BigBet: 20, Ante: 1, BringIn: 3, SmallBlind: 5, BigBlind: 10}
+	variants := stakes.EightGame()
+	rotated := rotation.NewRotation(rotation.Hands, 1, variants, button.NewManager(button.DeadButton, s), nil)
+	var recorder announcements
+	for index := range variants {
+		hand := &hands.Hand{
+			GameID:      1,
+			TableID:     1,
+			HandID:      uint64(index + 1),
+			Broadcaster: environment.NewBroadcaster(s, &recorder),
+			Shuffler:    unshuffled{},
+			Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+				if options.CanCheck {
+					return hands.Decision{Action: actions.Check}
+				}
+				return hands.Decision{Action: actions.Call}
+			}),
+		}
+		if err := rotated.Play(hand); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if len(recorder) != len(variants) {
+		t.Fatalf("expected %d announcements, got %v", len(variants), recorder)
+	}
+	for index, variant := range variants {
+		if recorder[index] != variant.Name {
+			t.Errorf("expected %s to be announced, not %s", variant.Name, recorder[index])
+		}
+	}
+	total := uint64(0)
+	for _, seat := range s {
+		total += seat.Stack()
+		if seat.Status() != seats.Waiting || len(seat.Cards(true)) != 0 {
+			t.Errorf("expected seat %d to be cleared after the hands", seat.SeatID())
+		}
+	}
+	if total != 3000 {
+		t.Errorf("expected the chips to be kept among the seats, got %d", total)
+	}
+}
@@ -1,4 +1,19 @@
 package omaha
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/omaha/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/community"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Omaha: four hole cards, and the best high hand using
+// exactly two of them and three cards of the board. It
+// is usually played with a pot-limit structure.
+var Game = community.Game{HoleCards: 4, High: high.Power}
+
+// Plays a whole hand of Omaha.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
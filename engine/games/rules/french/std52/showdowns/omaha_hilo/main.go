@@ -1,4 +1,23 @@
 package omaha_hilo
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/omaha/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/omaha/low"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/community"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Omaha Hi/Lo (Omaha-8): four hole cards, and the pots
+// split between the best high hand and the best eight
+// or better low hand (each one using exactly two hole
+// cards). When no hand qualifies for low, the best high
+// hand scoops.
+var Game = community.Game{HoleCards: 4, High: high.Power, Low: low.Power, Qualifies: common.EightOrBetter}
+
+// Plays a whole hand of Omaha Hi/Lo.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
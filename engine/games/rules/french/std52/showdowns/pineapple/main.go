@@ -0,0 +1,19 @@
+package pineapple
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/community"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// Pineapple: three hole cards, one of them discarded
+// after the pre-flop betting round, and then played as
+// Texas Hold'Em.
+var Game = community.Game{HoleCards: 3, High: high.Power, Discards: 1, DiscardStreet: 0}
+
+// Plays a whole hand of Pineapple.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
@@ -1,4 +1,18 @@
 package razz
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/low"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/stud"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Razz: seven-card stud, where the best ace-to-five low
+// hand (with no qualification) wins the whole pot. The
+// highest up card posts the bring-in.
+var Game = stud.Game{Low: low.Power}
+
+// Plays a whole hand of Razz.
+func Play(hand *hands.Hand, stakes forced.Stud) {
+	Game.Play(hand, stakes)
+}
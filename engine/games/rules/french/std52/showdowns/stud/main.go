@@ -0,0 +1,161 @@
+package stud
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/deck"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
+
+// The streets dealt after the third one, telling whether
+// the card is dealt face up (the fourth, fifth and sixth
+// streets) or face down (the seventh street).
+var Streets = []bool{true, true, true, false}
+
+// A seven-card stud game (e.g. Stud, Stud Hi/Lo or Razz)
+// is defined by the way the hands are evaluated. Lowball
+// games (e.g. Razz) only have an evaluator for the low
+// hands: there, the bring-in is posted by the highest up
+// card (Aces being low) and the lowest board acts first.
+// Otherwise, the bring-in is posted by the lowest up card
+// (Aces being high) and the highest board acts first. In
+// both cases, the suits break the ties for the bring-in.
+type Game struct {
+	High      hands.Evaluator
+	Low       hands.Evaluator
+	Qualifies func(power uint64) bool
+}
+
+// Tells whether this is a lowball game.
+func (game Game) lowball() bool {
+	return game.High == nil
+}
+
+// Gets the up cards of a seat.
+func upCards(seat seats.Seat) []cards.Card {
+	var result []cards.Card
+	for _, card := range seat.Cards(false) {
+		if card != nil {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// Gets the seat posting the bring-in, according to the
+// last up card of each remaining seat.
+func (game Game) bringIn(hand *hands.Hand) seats.Seat {
+	var result seats.Seat
+	best := 0
+	for _, seat := range hand.Remaining() {
+		shown := upCards(seat)
+		if len(shown) == 0 {
+			continue
+		}
+		if game.lowball() {
+			if value := common.LowCardValue(shown[len(shown)-1]); result == nil || value > best {
+				result, best = seat, value
+			}
+		} else if value := common.CardValue(shown[len(shown)-1]); result == nil || value < best {
+			result, best = seat, value
+		}
+	}
+	return result
+}
+
+// Gets the index, among the seats dealt in, of the remaining
+// seat having the best board (i.e. the best combination of
+// up cards), which acts first. Ties are broken by the order
+// of the seats.
+func (game Game) firstToAct(hand *hands.Hand) int {
+	result := -1
+	best := uint64(0)
+	for index, seat := range hand.Seats {
+		if status := seat.Status(); status != seats.Active && status != seats.AllIn {
+			continue
+		}
+		if game.lowball() {
+			bits, _ := common.PickAll(upCards(seat), common.LowballRanks)
+			if power := common.Std52LowballPower(bits); result == -1 || power < best {
+				result, best = index, power
+			}
+		} else {
+			bits, _ := common.PickAll(upCards(seat), common.HighRanks)
+			if power := common.Std52HighPower(bits, false); result == -1 || power > best {
+				result, best = index, power
+			}
+		}
+	}
+	return result
+}
+
+// Makes a seat post the bring-in. Its player is asked
+// whether to complete it to the small bet, which is done
+// by betting (any other decision posts the bring-in).
+func postBringIn(hand *hands.Hand, seat seats.Seat, bringIn uint64) {
+	completion := hand.Structure.BetSize(0)
+	complete := false
+	if seat.Status() == seats.Active && completion > bringIn && seat.Stack() > bringIn {
+		decision := hand.Input.Act(hand, seat, betting.Options{
+			ToCall:    bringIn,
+			CanBet:    true,
+			MinAmount: completion,
+			MaxAmount: completion,
+		})
+		complete = decision.Action == actions.Bet || decision.Action == actions.Raise ||
+			decision.Action == actions.AllIn
+	}
+	forced.PostBringIn(hand.GameID, hand.TableID, seat, bringIn, completion, complete, hand.Broadcaster)
+}
+
+// Deals a card to each remaining seat, after burning one.
+// When the deck runs short (e.g. with eight seats reaching
+// the seventh street) a single community card is dealt,
+// instead, burning a card only if one remains for it.
+func deal(hand *hands.Hand, shown bool) {
+	if hand.Deck.Len() > len(hand.Remaining()) {
+		hand.Burn()
+		hand.DealAround(shown)
+	} else {
+		if hand.Deck.Len() > 1 {
+			hand.Burn()
+		}
+		hand.RevealCommunity(1)
+	}
+}
+
+// Plays a whole hand of this game. The hand context must
+// have the seats dealt in (in table order) and the betting
+// structure, whose small bet is the completion of the bring
+// in. The antes are posted, two down cards and an up card
+// are dealt to each seat and the bring-in is posted, which
+// opens the betting in the third street. Then, the remaining
+// streets are dealt and bet, starting from the best board.
+// If more than one seat remains at the end, the hands are
+// shown and the pots are awarded to the best ones.
+func (game Game) Play(hand *hands.Hand, stakes forced.Stud) {
+	hand.Start(deck.Deck)
+	hand.Button = 0
+	hand.Pots = forced.PostAntes(hand.GameID, hand.TableID, hand.Seats, stakes.Ante, nil, hand.Broadcaster)
+	hand.DealAround(false, false, true)
+	bringIn := game.bringIn(hand)
+	postBringIn(hand, bringIn, stakes.BringIn)
+	hand.Bet(0, hand.OrderAfter(bringIn), bringIn)
+	for index, shown := range Streets {
+		if !hand.Contested() {
+			break
+		}
+		deal(hand, shown)
+		hand.Bet(uint8(index+1), hand.OrderFrom(game.firstToAct(hand)))
+	}
+	if hand.Contested() {
+		hand.Showdown(hands.Evaluation{High: game.High, Low: game.Low, Qualifies: game.Qualifies})
+	} else {
+		hand.AwardUncontested()
+	}
+	hand.Finish()
+}
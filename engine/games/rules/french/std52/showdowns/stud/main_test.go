@@ -0,0 +1,153 @@
+package stud
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/low"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"testing"
+)
+
+// Counts the community cards messages.
+type communityCounter struct {
+	count int
+}
+
+func (counter *communityCounter) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if _, ok := content.(tables.CommunityCardsHaveBeenDealt); ok {
+		counter.count++
+	}
+}
+
+// Keeps the deck as it is: the cards are dealt from
+// the top (i.e. from the aces of spades, downwards).
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(deck cards.Deck) {}
+
+// Gives each seat a down card and the given up cards.
+func makeBoards(boards ...[]french.Card) *hands.Hand {
+	s := seatstest.MakeActive(seatstest.Stacks(len(boards), 1000)...)
+	for index, board := range boards {
+		seatCards := []*seats.SeatCard{seats.NewSeatCard(french.H7)}
+		for _, card := range board {
+			seatCards = append(seatCards, seats.NewSeatShownCard(card))
+		}
+		s[index].AddCards(seatCards)
+	}
+	return &hands.Hand{Seats: s}
+}
+
+func TestBringIn(t *testing.T) {
+	hand := makeBoards([]french.Card{french.D2}, []french.Card{french.C2}, []french.Card{french.SK})
+	if seat := (Game{High: high.Power}).bringIn(hand); seat != hand.Seats[1] {
+		t.Errorf("expected the deuce of clubs to post the bring-in")
+	}
+	hand = makeBoards([]french.Card{french.HK}, []french.Card{french.SK}, []french.Card{french.DA})
+	if seat := (Game{Low: low.Power}).bringIn(hand); seat != hand.Seats[1] {
+		t.Errorf("expected the king of spades to post the bring-in")
+	}
+}
+
+func TestFirstToAct(t *testing.T) {
+	hand := makeBoards(
+		[]french.Card{french.C9, french.D9},
+		[]french.Card{french.SA, french.SK},
+		[]french.Card{french.H2, french.C3},
+		[]french.Card{french.D2, french.S3},
+	)
+	if index := (Game{High: high.Power}).firstToAct(hand); index != 0 {
+		t.Errorf("expected the pair of nines to act first, not seat %d", index+1)
+	}
+	if index := (Game{Low: low.Power}).firstToAct(hand); index != 2 {
+		t.Errorf("expected the first 3-2 board to act first, not seat %d", index+1)
+	}
+	hand.Seats[2].SetStatus(seats.Folded)
+	if index := (Game{Low: low.Power}).firstToAct(hand); index != 3 {
+		t.Errorf("expected the remaining 3-2 board to act first, not seat %d", index+1)
+	}
+}
+
+func play(game Game, count int, asked *[]uint8) ([]seats.Seat, *communityCounter) {
+	s := seatstest.MakeActive(seatstest.Stacks(count, 1000)...)
+	counter := &communityCounter{}
+	hand := &hands.Hand{
+		GameID:      1,
+		TableID:     1,
+		HandID:      1,
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, counter),
+		Shuffler:    unshuffled{},
+		Input: hands.InputFunc(func(hand *hands.Hand, seat seats.Seat, options betting.Options) hands.Decision {
+			if asked != nil {
+				*asked = append(*asked, seat.SeatID())
+			}
+			if options.CanCheck {
+				return hands.Decision{Action: actions.Check}
+			}
+			return hands.Decision{Action: actions.Call}
+		}),
+		Structure: structures.FixedLimit{SmallBet: 10, BigBet: 20, BigStreet: 2},
+	}
+	game.Play(hand, forced.Stud{Ante: 1, BringIn: 2})
+	return s, counter
+}
+
+func testStacks(t *testing.T, s []seats.Seat, stacks ...uint64) {
+	for index, stack := range stacks {
+		if s[index].Stack() != stack {
+			t.Errorf("expected seat %d to have %d chips, got %d", index+1, stack, s[index].Stack())
+		}
+	}
+}
+
+func TestHigh(t *testing.T) {
+	// The up cards are 8s, 7s and 6s, so the third seat
+	// brings in, and the first one acts first afterwards
+	// (8s 4s). The first seat wins with the ace high.
+	var asked []uint8
+	s, counter := play(Game{High: high.Power}, 3, &asked)
+	testStacks(t, s, 1006, 997, 997)
+	if len(asked) < 4 || asked[0] != 3 || asked[1] != 1 || asked[2] != 2 || asked[3] != 1 {
+		t.Errorf("unexpected acting order: %v", asked)
+	}
+	if counter.count != 0 {
+		t.Errorf("expected no community cards")
+	}
+}
+
+func TestLowball(t *testing.T) {
+	// The first seat (8s up) brings in, and the third
+	// seat wins with 9-7-6-3-2.
+	var asked []uint8
+	s, _ := play(Game{Low: low.Power}, 3, &asked)
+	testStacks(t, s, 997, 997, 1006)
+	if len(asked) == 0 || asked[0] != 1 {
+		t.Errorf("unexpected acting order: %v", asked)
+	}
+}
+
+func TestShortDeck(t *testing.T) {
+	s, counter := play(Game{High: high.Power}, 8, nil)
+	if counter.count != 1 {
+		t.Errorf("expected a single community card, got %d messages", counter.count)
+	}
+	total := uint64(0)
+	for _, seat := range s {
+		total += seat.Stack()
+	}
+	if total != 8000 {
+		t.Errorf("expected all the chips to be awarded, got %d", total)
+	}
+}
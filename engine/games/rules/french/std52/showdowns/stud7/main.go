@@ -1,4 +1,17 @@
 package stud7
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/stud"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Seven-card stud: three down cards and four up cards,
+// and the best high hand out of any five among them.
+var Game = stud.Game{High: high.Power}
+
+// Plays a whole hand of Seven-card stud.
+func Play(hand *hands.Hand, stakes forced.Stud) {
+	Game.Play(hand, stakes)
+}
@@ -1,4 +1,20 @@
 package stud7_hilo
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/high"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card7/low"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/stud"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// Seven-card stud Hi/Lo (Stud-8): the pots split between
+// the best high hand and the best eight or better low hand.
+// When no hand qualifies for low, the best high hand scoops.
+var Game = stud.Game{High: high.Power, Low: low.Power, Qualifies: common.EightOrBetter}
+
+// Plays a whole hand of Seven-card stud Hi/Lo.
+func Play(hand *hands.Hand, stakes forced.Stud) {
+	Game.Play(hand, stakes)
+}
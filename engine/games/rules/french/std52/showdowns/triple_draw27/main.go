@@ -1,4 +1,19 @@
 package triple_draw27
 
-// TODO after defining the showdown engine interface, define this implementation.
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/card5/low27"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/showdowns/drawing"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+)
 
+// 2-7 Triple draw: five cards, three draws, and the best
+// deuce-to-seven low hand (where straights and flushes
+// count, and Aces are high) wins.
+var Game = drawing.Game{Cards: 5, Draws: 3, Low: low27.Power}
+
+// Plays a whole hand of 2-7 Triple draw.
+func Play(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+	Game.Play(hand, positions, blinds)
+}
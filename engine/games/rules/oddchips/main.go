@@ -0,0 +1,97 @@
+package oddchips
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"sort"
+)
+
+// When a pot cannot be evenly divided among its tied
+// winners, some winners get one extra chip each. The
+// rule to choose those winners varies among rooms and
+// games, so it is given as a policy which sorts the
+// winners of a pot by their priority: the first ones
+// in the result will get the extra chips.
+//
+// Policies may use the button position (0 means "no
+// button in particular", e.g. in stud games) and the
+// cards of the winners, which are revealed at this
+// point.
+type Policy interface {
+	Prioritize(winners []seats.Seat, button uint8) []seats.Seat
+}
+
+// The default policy keeps the winners in the order
+// they are given. Winners come in the order they
+// showed their cards, so the first ones to show
+// get the extra chips.
+type ShowdownOrder struct{}
+
+// Returns the winners in the same order.
+func (ShowdownOrder) Prioritize(winners []seats.Seat, button uint8) []seats.Seat {
+	return winners
+}
+
+// This policy gives the extra chips to the winners
+// sitting closer to the left of the button. When
+// no button is given, seat IDs are sorted from the
+// lowest one.
+type LeftOfButton struct{}
+
+// Sorts the winners by their distance to the left
+// of the button. The button itself is the farthest
+// seat from its left.
+func (LeftOfButton) Prioritize(winners []seats.Seat, button uint8) []seats.Seat {
+	sorted := append(make([]seats.Seat, 0, len(winners)), winners...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// Distances wrap around the table since
+		// they are computed as uint8 values.
+		return sorted[i].SeatID()-button-1 < sorted[j].SeatID()-button-1
+	})
+	return sorted
+}
+
+// This policy gives the extra chips to the winners
+// having the highest card, among all of their cards,
+// considering the rank and then the suit. This rule
+// is typical in stud games. The value of each card
+// is given by a function, since it depends on the
+// card set being used.
+type HighestCard struct {
+	Value func(card cards.Card) int
+}
+
+// Gets the greatest value among the seat's cards.
+func (policy HighestCard) highest(seat seats.Seat) int {
+	highest := -1
+	for _, card := range seat.Cards(true) {
+		if value := policy.Value(card); value > highest {
+			highest = value
+		}
+	}
+	return highest
+}
+
+// Sorts the winners by their highest card, from the
+// highest to the lowest.
+func (policy HighestCard) Prioritize(winners []seats.Seat, button uint8) []seats.Seat {
+	sorted := append(make([]seats.Seat, 0, len(winners)), winners...)
+	highest := map[seats.Seat]int{}
+	for _, seat := range sorted {
+		highest[seat] = policy.highest(seat)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return highest[sorted[i]] > highest[sorted[j]]
+	})
+	return sorted
+}
+
+// When a pot is split between the high and the low
+// hands in hi/lo games, the odd chip goes to the
+// high hand. This function returns the amounts for
+// the high and the low halves.
+func SplitHighLow(amount uint64) (high uint64, low uint64) {
+	low = amount / 2
+	high = amount - low
+	return
+}
@@ -0,0 +1,73 @@
+package oddchips
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	. "github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"github.com/luismasuelli/poker-go/engine/games/rules/french/std52/evaluators/common"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+func makeSeat(seatID uint8, hand ...cards.Card) seats.Seat {
+	seat := seats.NewBaseSeat(seatID)
+	seat.Sit(&seatstest.Player{}, 1000)
+	seatCards := make([]*seats.SeatCard, len(hand))
+	for index, card := range hand {
+		seatCards[index] = seats.NewSeatCard(card)
+	}
+	seat.AddCards(seatCards)
+	return seat
+}
+
+func testOrder(t *testing.T, label string, sorted []seats.Seat, seatIDs ...uint8) {
+	if len(sorted) != len(seatIDs) {
+		t.Errorf("%s: expected %d seats, got %d", label, len(seatIDs), len(sorted))
+		return
+	}
+	for index, seat := range sorted {
+		if seat.SeatID() != seatIDs[index] {
+			t.Errorf("%s: expected seat %d at index %d, got %d", label, seatIDs[index], index, seat.SeatID())
+		}
+	}
+}
+
+func TestShowdownOrder(t *testing.T) {
+	winners := []seats.Seat{makeSeat(5), makeSeat(2), makeSeat(7)}
+	testOrder(t, "showdown order", ShowdownOrder{}.Prioritize(winners, 3), 5, 2, 7)
+}
+
+func TestLeftOfButton(t *testing.T) {
+	winners := []seats.Seat{makeSeat(2), makeSeat(9), makeSeat(5), makeSeat(7)}
+	testOrder(t, "button at 5", LeftOfButton{}.Prioritize(winners, 5), 7, 9, 2, 5)
+	testOrder(t, "button at 9", LeftOfButton{}.Prioritize(winners, 9), 2, 5, 7, 9)
+	testOrder(t, "button at 1", LeftOfButton{}.Prioritize(winners, 1), 2, 5, 7, 9)
+	testOrder(t, "no button", LeftOfButton{}.Prioritize(winners, 0), 2, 5, 7, 9)
+	if winners[0].SeatID() != 2 {
+		t.Errorf("the given winners must not be sorted in place")
+	}
+}
+
+func TestHighestCard(t *testing.T) {
+	policy := HighestCard{common.CardValue}
+	winners := []seats.Seat{
+		makeSeat(1, C2, D3, H4, S5, C6, D7, HK),
+		makeSeat(2, H2, S3, C4, D5, H6, S7, SK),
+		makeSeat(3, D2, H3, S4, C5, D6, H7, DK),
+	}
+	testOrder(t, "highest king", policy.Prioritize(winners, 0), 2, 1, 3)
+	winners = []seats.Seat{
+		makeSeat(1, C2, D3, H4, S5, CA),
+		makeSeat(2, H2, S3, C4, D5, DA),
+	}
+	testOrder(t, "highest ace", policy.Prioritize(winners, 0), 2, 1)
+}
+
+func TestSplitHighLow(t *testing.T) {
+	for _, amounts := range [][3]uint64{{100, 50, 50}, {101, 51, 50}, {1, 1, 0}, {0, 0, 0}} {
+		high, low := SplitHighLow(amounts[0])
+		if high != amounts[1] || low != amounts[2] {
+			t.Errorf("splitting %d: expected %d/%d, got %d/%d", amounts[0], amounts[1], amounts[2], high, low)
+		}
+	}
+}
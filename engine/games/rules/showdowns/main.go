@@ -64,7 +64,11 @@ const (
 	// in modes like Pai gow.
 	Front
 	Back
+	// "First board" and "Second board" showdowns
+	// are used in double-board pots.
+	FirstBoard
+	SecondBoard
 )
 
 // All the modes to check / iterate for each hand.
-var ModesToCheck = []Mode{Standard, High, Low, Front, Back}
\ No newline at end of file
+var ModesToCheck = []Mode{Standard, High, Low, Front, Back, FirstBoard, SecondBoard}
\ No newline at end of file
@@ -0,0 +1,95 @@
+package showdowns
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/rules/oddchips"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+// Gets all the seats present in a podium.
+func (podium Podium) seats() map[seats.Seat]bool {
+	result := map[seats.Seat]bool{}
+	for _, position := range podium {
+		for _, seat := range position {
+			result[seat] = true
+		}
+	}
+	return result
+}
+
+// Creates a new pot with the given amount and the seats
+// of the original pot that are also present in a podium.
+func intersect(amount uint64, pot *pots.Pot, ranked map[seats.Seat]bool) *pots.Pot {
+	involvedSeats := make([]seats.Seat, 0)
+	for _, seat := range pot.Seats() {
+		if _, ok := ranked[seat]; ok {
+			involvedSeats = append(involvedSeats, seat)
+		}
+	}
+	return pots.NewPot(amount, involvedSeats)
+}
+
+// Splits the collected pots (the main pot and the side
+// pots) among the modes of the computed podiums, so the
+// result can be awarded.
+//
+// Games with only one mode get the same collected pots.
+// In hi/lo games, each pot is divided in a high half
+// and a low half. The odd chip goes to the high half,
+// and the seats involved in each half are the seats of
+// the original pot that are ranked in the podium of
+// that mode. If no seat involved in a pot qualified for
+// low (or the low podium is absent), the high hands
+// scoop that pot, and an empty pot (with no involved
+// seats) keeps its place in the low pots, so the pot
+// indices match in both modes.
+//
+// In double-board pots, each pot is divided in halves,
+// one per board, and the odd chip goes to the first
+// board.
+func SplitPots(collected []*pots.Pot, podiums Podiums) Pots {
+	result := Pots{}
+	if firstPodium, ok := podiums[FirstBoard]; ok {
+		firstSeats := firstPodium.seats()
+		secondSeats := podiums[SecondBoard].seats()
+		firstPots := make([]*pots.Pot, 0, len(collected))
+		secondPots := make([]*pots.Pot, 0, len(collected))
+		for _, pot := range collected {
+			firstAmount, secondAmount := oddchips.SplitHighLow(pot.Amount())
+			firstPots = append(firstPots, intersect(firstAmount, pot, firstSeats))
+			secondPots = append(secondPots, intersect(secondAmount, pot, secondSeats))
+		}
+		result[FirstBoard] = firstPots
+		result[SecondBoard] = secondPots
+		return result
+	}
+	highPodium, ok := podiums[High]
+	if !ok {
+		for mode := range podiums {
+			result[mode] = collected
+		}
+		return result
+	}
+
+	lowPodium := podiums[Low]
+	highSeats := highPodium.seats()
+	lowSeats := lowPodium.seats()
+	highPots := make([]*pots.Pot, 0, len(collected))
+	lowPots := make([]*pots.Pot, 0, len(collected))
+	for _, pot := range collected {
+		lowPot := intersect(0, pot, lowSeats)
+		if len(lowPot.Seats()) == 0 {
+			highPots = append(highPots, intersect(pot.Amount(), pot, highSeats))
+			lowPots = append(lowPots, pots.NewPot(0, nil))
+		} else {
+			highAmount, lowAmount := oddchips.SplitHighLow(pot.Amount())
+			highPots = append(highPots, intersect(highAmount, pot, highSeats))
+			lowPots = append(lowPots, intersect(lowAmount, pot, lowSeats))
+		}
+	}
+	result[High] = highPots
+	if lowPodium != nil {
+		result[Low] = lowPots
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package showdowns
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+func testPot(t *testing.T, label string, pot *pots.Pot, amount uint64, involvedSeats ...seats.Seat) {
+	if pot.Amount() != amount {
+		t.Errorf("%s: expected amount %d, got %d", label, amount, pot.Amount())
+	}
+	if len(pot.Seats()) != len(involvedSeats) {
+		t.Errorf("%s: expected %d involved seats, got %d", label, len(involvedSeats), len(pot.Seats()))
+		return
+	}
+	for _, seat := range involvedSeats {
+		if !pot.Involves(seat) {
+			t.Errorf("%s: expected seat %d to be involved", label, seat.SeatID())
+		}
+	}
+}
+
+func TestSplitStandard(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000)
+	collected := []*pots.Pot{pots.NewPot(100, s)}
+	result := SplitPots(collected, Podiums{Standard: Podium{{s[0]}, {s[1]}}})
+	if len(result) != 1 || len(result[Standard]) != 1 || result[Standard][0] != collected[0] {
+		t.Errorf("expected the collected pots to be kept as standard pots")
+	}
+}
+
+func TestSplitHighLow(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	collected := []*pots.Pot{pots.NewPot(301, s), pots.NewPot(200, s[1:])}
+	result := SplitPots(collected, Podiums{
+		High: Podium{{s[0]}, {s[1]}, {s[2]}},
+		Low:  Podium{{s[1]}, {s[2]}},
+	})
+	if len(result[High]) != 2 || len(result[Low]) != 2 {
+		t.Errorf("expected 2 high pots and 2 low pots")
+		return
+	}
+	testPot(t, "high main", result[High][0], 151, s[0], s[1], s[2])
+	testPot(t, "low main", result[Low][0], 150, s[1], s[2])
+	testPot(t, "high side", result[High][1], 100, s[1], s[2])
+	testPot(t, "low side", result[Low][1], 100, s[1], s[2])
+}
+
+func TestSplitScoop(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	collected := []*pots.Pot{pots.NewPot(300, s), pots.NewPot(200, s[:2])}
+	// Only the third seat qualified for low, but it
+	// is not involved in the side pot.
+	result := SplitPots(collected, Podiums{
+		High: Podium{{s[0], s[1]}, {s[2]}},
+		Low:  Podium{{s[2]}},
+	})
+	testPot(t, "high main", result[High][0], 150, s[0], s[1], s[2])
+	testPot(t, "low main", result[Low][0], 150, s[2])
+	testPot(t, "high side", result[High][1], 200, s[0], s[1])
+	testPot(t, "low side", result[Low][1], 0)
+	// No seat qualified for low: high scoops all.
+	result = SplitPots(collected, Podiums{High: Podium{{s[0]}, {s[1], s[2]}}, Low: nil})
+	if _, ok := result[Low]; ok {
+		t.Errorf("expected no low pots")
+	}
+	testPot(t, "high main", result[High][0], 300, s[0], s[1], s[2])
+	testPot(t, "high side", result[High][1], 200, s[0], s[1])
+}
+
+func TestSplitQuartering(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	collected := []*pots.Pot{pots.NewPot(300, s)}
+	result := SplitPots(collected, Podiums{
+		High: Podium{{s[0]}, {s[1]}, {s[2]}},
+		Low:  Podium{{s[0], s[1]}, {s[2]}},
+	})
+	winners, amount, remainder := result[High][0].Award([]seats.Seat{s[0]})
+	if len(winners) != 1 || amount != 150 || remainder != 0 {
+		t.Errorf("expected the high winner to get 150, got %d", amount)
+	}
+	winners, amount, remainder = result[Low][0].Award([]seats.Seat{s[0], s[1]})
+	if len(winners) != 2 || amount != 75 || remainder != 0 {
+		t.Errorf("expected each low winner to get 75, got %d", amount)
+	}
+}
+
+func TestSplitBoards(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	collected := []*pots.Pot{pots.NewPot(301, s), pots.NewPot(200, s[1:])}
+	result := SplitPots(collected, Podiums{
+		FirstBoard:  Podium{{s[0]}, {s[1]}, {s[2]}},
+		SecondBoard: Podium{{s[2]}, {s[1], s[0]}},
+	})
+	if len(result) != 2 || len(result[FirstBoard]) != 2 || len(result[SecondBoard]) != 2 {
+		t.Errorf("expected 2 pots in each board")
+		return
+	}
+	testPot(t, "first board main", result[FirstBoard][0], 151, s[0], s[1], s[2])
+	testPot(t, "second board main", result[SecondBoard][0], 150, s[0], s[1], s[2])
+	testPot(t, "first board side", result[FirstBoard][1], 100, s[1], s[2])
+	testPot(t, "second board side", result[SecondBoard][1], 100, s[1], s[2])
+}
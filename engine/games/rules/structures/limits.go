@@ -0,0 +1,91 @@
+package structures
+
+// No-limit betting: the minimum bet is the big blind,
+// and a raise must be at least as big as the last full
+// bet or raise. Seats may bet their whole stack.
+type NoLimit struct {
+	BigBlind uint64
+}
+
+// The bet size is the big blind in all the streets.
+func (noLimit NoLimit) BetSize(street uint8) uint64 {
+	return noLimit.BigBlind
+}
+
+// Seats may bet from the minimum up to their stack.
+func (noLimit NoLimit) Limits(situation Situation) (uint64, uint64) {
+	return minAmount(situation, noLimit.BigBlind), situation.AllIn()
+}
+
+// There is no cap in no-limit.
+func (noLimit NoLimit) Capped(situation Situation) bool {
+	return false
+}
+
+// Pot-limit betting: the minimum amounts are the same
+// of no-limit, but the maximum raise is the size of
+// the pot after calling (i.e. the collected pots, the
+// bets in this round and the amount to call).
+type PotLimit struct {
+	BigBlind uint64
+}
+
+// The bet size is the big blind in all the streets.
+func (potLimit PotLimit) BetSize(street uint8) uint64 {
+	return potLimit.BigBlind
+}
+
+// Seats may bet from the minimum up to the pot.
+func (potLimit PotLimit) Limits(situation Situation) (uint64, uint64) {
+	toCall := uint64(0)
+	if pot := situation.Seat.Pot(); pot < situation.CurrentBet {
+		toCall = situation.CurrentBet - pot
+	}
+	max := situation.CurrentBet + situation.Pot() + toCall
+	return minAmount(situation, potLimit.BigBlind), bound(situation, max)
+}
+
+// There is no cap in pot-limit.
+func (potLimit PotLimit) Capped(situation Situation) bool {
+	return false
+}
+
+// Fixed-limit betting: bets and raises have a fixed
+// size, which is the small bet in the first streets
+// and the big bet since the given street (e.g. 2 for
+// the turn, in Hold'Em, or 2 for the fifth street, in
+// Stud). The number of bets in a round is capped (0
+// means no cap), unless only two seats remain.
+type FixedLimit struct {
+	SmallBet  uint64
+	BigBet    uint64
+	BigStreet uint8
+	Cap       uint8
+}
+
+// The bet size is the small or big bet, depending on
+// the street.
+func (fixedLimit FixedLimit) BetSize(street uint8) uint64 {
+	if street >= fixedLimit.BigStreet {
+		return fixedLimit.BigBet
+	}
+	return fixedLimit.SmallBet
+}
+
+// Seats may only bet or raise to the next bet level.
+// A smaller current bet (e.g. a bring-in, or a short
+// all-in) is completed to the level.
+func (fixedLimit FixedLimit) Limits(situation Situation) (uint64, uint64) {
+	size := fixedLimit.BetSize(situation.Street)
+	amount := (situation.CurrentBet/size + 1) * size
+	return amount, bound(situation, amount)
+}
+
+// Tells whether the current bet reached the cap, when
+// more than two seats remain in the hand.
+func (fixedLimit FixedLimit) Capped(situation Situation) bool {
+	if fixedLimit.Cap == 0 || situation.Remaining() <= 2 {
+		return false
+	}
+	return situation.CurrentBet/fixedLimit.BetSize(situation.Street) >= uint64(fixedLimit.Cap)
+}
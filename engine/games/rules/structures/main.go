@@ -0,0 +1,95 @@
+package structures
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+// The situation of a seat in a betting round, when
+// it is about to bet or raise. Amounts are "to"
+// amounts (i.e. the total put in the round).
+type Situation struct {
+	// The index of the betting round in the hand
+	// (e.g. 0 for the pre-flop in Hold'Em, or 0
+	// for the third street in Stud).
+	Street uint8
+	// The seats taking part in the betting round,
+	// including the ones that folded in it.
+	Seats []seats.Seat
+	// The pots collected in the former rounds.
+	Collected []*pots.Pot
+	// The seat about to act.
+	Seat seats.Seat
+	// The current bet in the round.
+	CurrentBet uint64
+	// The size of the last full bet or raise.
+	LastRaise uint64
+}
+
+// Counts the seats that did not fold.
+func (situation Situation) Remaining() int {
+	count := 0
+	for _, seat := range situation.Seats {
+		if status := seat.Status(); status == seats.Active || status == seats.AllIn {
+			count++
+		}
+	}
+	return count
+}
+
+// Computes the whole pot: the collected pots and the
+// bets in the current round.
+func (situation Situation) Pot() uint64 {
+	total := uint64(0)
+	for _, pot := range situation.Collected {
+		total += pot.Amount()
+	}
+	for _, seat := range situation.Seats {
+		total += seat.Pot()
+	}
+	return total
+}
+
+// Gets the total the seat can put in the round: its
+// pot and its whole stack.
+func (situation Situation) AllIn() uint64 {
+	return situation.Seat.Pot() + situation.Seat.Stack()
+}
+
+// A betting structure tells the legal amounts for
+// bets and raises. The betting round keeps track of
+// the bets, and asks the structure for the limits.
+// Amounts below the minimum are allowed only when
+// they put the seat all-in.
+type Structure interface {
+	// The size of the minimum bet in a street. This
+	// is also the size of the first raise in it.
+	BetSize(street uint8) uint64
+	// The minimum and maximum amounts a seat can
+	// bet or raise to. The maximum is never greater
+	// than the amount putting the seat all-in, but
+	// the minimum may be (then, only an all-in for
+	// less is allowed).
+	Limits(situation Situation) (uint64, uint64)
+	// Tells whether no more raises are allowed in
+	// the current round.
+	Capped(situation Situation) bool
+}
+
+// Gets the minimum bet or raise for the structures
+// where raises must be at least as big as the last
+// full bet or raise.
+func minAmount(situation Situation, betSize uint64) uint64 {
+	if situation.CurrentBet == 0 {
+		return betSize
+	}
+	return situation.CurrentBet + situation.LastRaise
+}
+
+// Bounds an amount to what the seat has.
+func bound(situation Situation, amount uint64) uint64 {
+	if allIn := situation.AllIn(); amount > allIn {
+		return allIn
+	}
+	return amount
+}
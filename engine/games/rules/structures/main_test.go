@@ -0,0 +1,64 @@
+package structures
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Creates active seats with the given stacks and
+// bets in the current round.
+func makeSeats(stacks []uint64, bets []uint64) []seats.Seat {
+	result := seatstest.MakeActive(stacks...)
+	for index, seat := range result {
+		seat.SubStack(bets[index])
+		seat.AddPot(bets[index])
+	}
+	return result
+}
+
+func testLimits(t *testing.T, structure Structure, situation Situation, min, max uint64) {
+	if actualMin, actualMax := structure.Limits(situation); actualMin != min || actualMax != max {
+		t.Errorf("expected limits %d-%d, got %d-%d", min, max, actualMin, actualMax)
+	}
+}
+
+func TestNoLimit(t *testing.T) {
+	structure := NoLimit{BigBlind: 10}
+	s := makeSeats([]uint64{1000, 50}, []uint64{0, 0})
+	testLimits(t, structure, Situation{Seats: s, Seat: s[0]}, 10, 1000)
+	testLimits(t, structure, Situation{Seats: s, Seat: s[0], CurrentBet: 30, LastRaise: 30}, 60, 1000)
+	testLimits(t, structure, Situation{Seats: s, Seat: s[1], CurrentBet: 30, LastRaise: 30}, 60, 50)
+	if structure.Capped(Situation{Seats: s, Seat: s[0], CurrentBet: 1000}) {
+		t.Errorf("expected no cap in no-limit")
+	}
+}
+
+func TestPotLimit(t *testing.T) {
+	structure := PotLimit{BigBlind: 10}
+	s := makeSeats([]uint64{1000, 1000, 100}, []uint64{0, 20, 0})
+	collected := []*pots.Pot{pots.NewPot(60, s)}
+	// The pot after calling is 60 + 20 + 20 = 100.
+	testLimits(t, structure, Situation{Seats: s, Collected: collected, Seat: s[0], CurrentBet: 20, LastRaise: 20},
+		40, 120)
+	// The third seat cannot put more than 100.
+	testLimits(t, structure, Situation{Seats: s, Collected: collected, Seat: s[2], CurrentBet: 20, LastRaise: 20},
+		40, 100)
+}
+
+func TestFixedLimit(t *testing.T) {
+	structure := FixedLimit{SmallBet: 10, BigBet: 20, BigStreet: 2, Cap: 4}
+	s := makeSeats([]uint64{1000, 1000, 1000}, []uint64{0, 0, 0})
+	testLimits(t, structure, Situation{Street: 1, Seats: s, Seat: s[0]}, 10, 10)
+	testLimits(t, structure, Situation{Street: 2, Seats: s, Seat: s[0], CurrentBet: 20}, 40, 40)
+	// A bring-in is completed to the small bet.
+	testLimits(t, structure, Situation{Street: 0, Seats: s, Seat: s[0], CurrentBet: 3}, 10, 10)
+	if !structure.Capped(Situation{Street: 0, Seats: s, Seat: s[0], CurrentBet: 40}) {
+		t.Errorf("expected the round to be capped after 4 bets")
+	}
+	s[2].SetStatus(seats.Folded)
+	if structure.Capped(Situation{Street: 0, Seats: s, Seat: s[0], CurrentBet: 40}) {
+		t.Errorf("expected no cap when heads-up")
+	}
+}
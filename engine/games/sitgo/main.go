@@ -0,0 +1,341 @@
+package sitgo
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games"
+	tableMessages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"github.com/luismasuelli/poker-go/engine/misc"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"math/rand"
+	"sort"
+	. "sync"
+)
+
+var ErrTooFewSeats = errors.New("a sit & go needs at least 2 seats")
+var ErrEmptyStack = errors.New("a sit & go needs a starting stack")
+var ErrBuyInRange = errors.New("the buy-in of a sit & go must have a single amount")
+var ErrNoLevels = errors.New("a sit & go needs at least one blind level")
+var ErrNotRegistering = errors.New("the sit & go is not registering players")
+var ErrAlreadyRegistered = errors.New("the player is already registered")
+var ErrNotRegistered = errors.New("the player is not registered")
+
+// A level of the blind schedule, lasting the given number
+// of hands (0 means forever). The last level lasts forever
+// anyway.
+type Level struct {
+	Blinds forced.Blinds
+	Hands  int
+}
+
+// The blind levels of a tournament, in order.
+type Schedule []Level
+
+// Plays a whole hand of the tournament's variant, given the
+// hand context, the positions and the blinds of the current
+// level (e.g. holdem.Play).
+type Variant func(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds)
+
+// A Sit & Go is a single-table tournament. Players register
+// paying the buy-in (a ticket or a currency), and the game
+// starts when all the seats are taken: players are seated at
+// random with the same starting stack, and the blinds raise
+// according to the schedule. Eliminated players are paid the
+// prizes of their finishing places, and the game is destroyed
+// when a single player remains (the winner).
+type Game struct {
+	// Plays the hands.
+	Variant Variant
+	// The hand settings (e.g. the inputs, the shuffler
+	// and the betting structure).
+	Template hands.Hand
+	// The random source of the seating (nil means the
+	// default one).
+	Random *rand.Rand
+
+	id         interface{}
+	caption    string
+	buyIn      *assets.BuyIn
+	prizes     assets.Prizes
+	size       int
+	stack      uint64
+	schedule   Schedule
+	parent     misc.Notifiable
+	status     games.GameStatus
+	registered []players.Player
+	places     []players.Player
+	table      *tables.Table
+	manager    *button.Manager
+	level      int
+	played     int
+	starting   map[seats.Seat]uint64
+	reason     interface{}
+	mutex      Mutex
+}
+
+// Creates a new Sit & Go, registering players, with the
+// buy-in (of a single amount), the prizes by finishing
+// place, the number of seats, the starting stack, the blind
+// schedule, and the parent notifiable of its messages. The
+// variant must be set before the game starts.
+func NewGame(id interface{}, caption string, buyIn *assets.BuyIn, prizes assets.Prizes, size int, stack uint64,
+	schedule Schedule, parent misc.Notifiable) (*Game, error) {
+	if size < 2 {
+		return nil, ErrTooFewSeats
+	}
+	if stack == 0 {
+		return nil, ErrEmptyStack
+	}
+	if buyIn.MinAmount() != buyIn.MaxAmount() {
+		return nil, ErrBuyInRange
+	}
+	if len(schedule) == 0 {
+		return nil, ErrNoLevels
+	}
+	return &Game{
+		id:       id,
+		caption:  caption,
+		buyIn:    buyIn,
+		prizes:   prizes,
+		size:     size,
+		stack:    stack,
+		schedule: schedule,
+		parent:   parent,
+		status:   games.Registering,
+	}, nil
+}
+
+// The ID of the game.
+func (game *Game) ID() interface{} {
+	return game.id
+}
+
+// The caption of the game.
+func (game *Game) Caption() string {
+	return game.caption
+}
+
+// The registered players and the seats.
+func (game *Game) Occupancy() (uint32, uint32) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return uint32(len(game.registered)), uint32(game.size)
+}
+
+// The status of the game: Registering, Playing (once all
+// the seats are taken) or Destroyed (once there is a
+// winner, or the game was cancelled).
+func (game *Game) Status() games.GameStatus {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return game.status
+}
+
+// The table of the game, or nil if it did not start yet.
+func (game *Game) Table() *tables.Table {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return game.table
+}
+
+// The players in their finishing places (the first one is
+// the winner), known so far. Places not decided yet are nil.
+func (game *Game) Places() []players.Player {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return append([]players.Player{}, game.places...)
+}
+
+// The reason why the game was cancelled (e.g. the error of
+// the dealer, or the value of a panic), if it was.
+func (game *Game) Reason() interface{} {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	return game.reason
+}
+
+// Gets the index of a registered player, or -1.
+func (game *Game) find(player players.Player) int {
+	for index, registered := range game.registered {
+		if registered.Identification() == player.Identification() {
+			return index
+		}
+	}
+	return -1
+}
+
+// Takes the buy-in from the player and registers it. The
+// game starts when all the seats are taken.
+func (game *Game) Register(player players.Player) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if game.status != games.Registering {
+		return ErrNotRegistering
+	}
+	if game.find(player) >= 0 {
+		return ErrAlreadyRegistered
+	}
+	if err := player.Take(game.buyIn.Asset(), game.buyIn.MinAmount()); err != nil {
+		return err
+	}
+	game.registered = append(game.registered, player)
+	if len(game.registered) == game.size {
+		game.start()
+	}
+	return nil
+}
+
+// Refunds the buy-in to the player and unregisters it. This
+// is only allowed before the game starts.
+func (game *Game) Unregister(player players.Player) error {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if game.status != games.Registering {
+		return ErrNotRegistering
+	}
+	index := game.find(player)
+	if index < 0 {
+		return ErrNotRegistered
+	}
+	game.registered = append(game.registered[:index], game.registered[index+1:]...)
+	return player.Add(game.buyIn.Asset(), game.buyIn.MinAmount())
+}
+
+// Seats the registered players at random, with the starting
+// stack, and starts the hand cycle.
+func (game *Game) start() {
+	game.status = games.Playing
+	game.places = make([]players.Player, game.size)
+	game.table = tables.NewTable(game.id, 1, game.size, game.parent)
+	shuffle := rand.Shuffle
+	if game.Random != nil {
+		shuffle = game.Random.Shuffle
+	}
+	seating := append([]players.Player{}, game.registered...)
+	shuffle(len(seating), func(i, j int) {
+		seating[i], seating[j] = seating[j], seating[i]
+	})
+	for index, seat := range game.table.Seats() {
+		_ = seat.Sit(seating[index], game.stack)
+	}
+	game.manager = button.NewManager(button.DeadButton, game.table.Seats())
+	game.table.Template = game.Template
+	game.table.Dealer = tables.DealerFunc(game.play)
+	game.table.AfterHand = game.eliminate
+	game.table.Stopped = game.stopped
+	game.table.Start()
+}
+
+// Plays the next hand with the blinds of the current level,
+// raising them (and announcing it) when the level is over.
+func (game *Game) play(hand *hands.Hand) error {
+	positions, err := game.manager.Next()
+	if err != nil {
+		return err
+	}
+	level := game.schedule[game.level]
+	if game.level < len(game.schedule)-1 && level.Hands != 0 && game.played >= level.Hands {
+		game.level++
+		game.played = 0
+		level = game.schedule[game.level]
+	}
+	if game.played == 0 {
+		hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tableMessages.BlindsHaveBeenRaised{
+			Level: game.level + 1,
+			Small: level.Blinds.Small,
+			Big:   level.Blinds.Big,
+			Ante:  level.Blinds.Ante,
+		})
+	}
+	game.played++
+	game.starting = make(map[seats.Seat]uint64, len(positions.DealtIn))
+	for _, seat := range positions.DealtIn {
+		game.starting[seat] = seat.Stack()
+	}
+	hand.Seats = positions.DealtIn
+	game.Variant(hand, positions, level.Blinds)
+	return nil
+}
+
+// Releases the players who lost all their chips in the hand,
+// in their finishing places: the ones starting the hand with
+// more chips finish in better places (ties go by table order).
+// When a single player remains, it wins and the game is
+// destroyed.
+func (game *Game) eliminate(table *tables.Table, hand *hands.Hand) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	var busted []seats.Seat
+	var winner seats.Seat
+	remaining := 0
+	for _, seat := range table.Seats() {
+		if seat.Player() == nil {
+			continue
+		} else if seat.Stack() == 0 {
+			busted = append(busted, seat)
+		} else {
+			winner = seat
+			remaining++
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return game.starting[busted[i]] > game.starting[busted[j]]
+	})
+	for index, seat := range busted {
+		hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.PlayerHasBeenEliminated{
+			Display: seat.Player().Display(),
+			Place:   remaining + index + 1,
+		})
+		game.finish(seat, remaining+index+1)
+	}
+	if remaining == 1 {
+		game.finish(winner, 1)
+		game.status = games.Destroyed
+	}
+}
+
+// Releases the seat and pays the prize of the place, if any,
+// to its player.
+func (game *Game) finish(seat seats.Seat, place int) {
+	player, _ := seat.Pop()
+	game.places[place-1] = player
+	if place <= len(game.prizes) {
+		prize := &game.prizes[place-1]
+		_ = player.Add(prize.Asset(), prize.Amount())
+	}
+}
+
+// Cancels the game if the hand cycle ended before there was
+// a winner (e.g. the dealer failed, or the variant panicked):
+// the reason is kept, and the players still sitting get their
+// buy-ins back (failed refunds are announced to the table, so
+// they can be settled later).
+func (game *Game) stopped(table *tables.Table, reason interface{}) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+	if game.status != games.Playing {
+		return
+	}
+	game.status = games.Destroyed
+	game.reason = reason
+	for _, seat := range table.Seats() {
+		player, _ := seat.Pop()
+		if player == nil {
+			continue
+		}
+		if err := player.Add(game.buyIn.Asset(), game.buyIn.MinAmount()); err != nil {
+			table.Broadcaster().NotifyTable(game.id, uint32(table.ID()), tableMessages.RefundHasFailed{
+				Player: player.Identification(),
+				Asset:  game.buyIn.Asset().ID(),
+				Amount: game.buyIn.MinAmount(),
+				Reason: err.Error(),
+			})
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package sitgo
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/forced"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+type asset struct {
+	id        string
+	assetType assets.AssetType
+}
+
+func (asset *asset) ID() interface{}        { return asset.id }
+func (asset *asset) Type() assets.AssetType { return asset.assetType }
+func (asset *asset) Caption() string        { return asset.id }
+
+var errCannotAfford = errors.New("cannot afford")
+
+type player struct {
+	name     string
+	balances map[assets.Asset]uint64
+	mutex    sync.Mutex
+}
+
+func newPlayer(name string, balance uint64) *player {
+	return &player{name: name, balances: map[assets.Asset]uint64{dollars: balance}}
+}
+
+func (player *player) Identification() interface{}                        { return player.name }
+func (player *player) Display() interface{}                               { return player.name }
+func (player *player) Notify(message interface{})                         {}
+func (player *player) FindHaving(asset assets.Asset) []players.Accounting { return nil }
+
+func (player *player) Get(asset assets.Asset) (uint64, error) {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	return player.balances[asset], nil
+}
+
+func (player *player) Add(asset assets.Asset, amount uint64) error {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	player.balances[asset] += amount
+	return nil
+}
+
+func (player *player) Take(asset assets.Asset, amount uint64) error {
+	player.mutex.Lock()
+	defer player.mutex.Unlock()
+	if player.balances[asset] < amount {
+		return errCannotAfford
+	}
+	player.balances[asset] -= amount
+	return nil
+}
+
+var dollars = &asset{"USD", assets.Currency}
+
+var schedule = Schedule{
+	{Blinds: forced.Blinds{Small: 10, Big: 20}, Hands: 1},
+	{Blinds: forced.Blinds{Small: 20, Big: 40}},
+}
+
+func checkBalance(t *testing.T, player *player, expected uint64) {
+	if balance, _ := player.Get(dollars); balance != expected {
+		t.Errorf("expected %s to have %d, got %d", player.name, expected, balance)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	buyIn := assets.NewBuyIn(dollars, 10, 10)
+	if _, err := NewGame(1, "SNG", buyIn, nil, 1, 1500, schedule, &seatstest.Notifiable{}); err != ErrTooFewSeats {
+		t.Errorf("expected ErrTooFewSeats, got %v", err)
+	}
+	if _, err := NewGame(1, "SNG", buyIn, nil, 3, 0, schedule, &seatstest.Notifiable{}); err != ErrEmptyStack {
+		t.Errorf("expected ErrEmptyStack, got %v", err)
+	}
+	if _, err := NewGame(1, "SNG", assets.NewBuyIn(dollars, 10, 20), nil, 3, 1500, schedule, &seatstest.Notifiable{}); err != ErrBuyInRange {
+		t.Errorf("expected ErrBuyInRange, got %v", err)
+	}
+	if _, err := NewGame(1, "SNG", buyIn, nil, 3, 1500, nil, &seatstest.Notifiable{}); err != ErrNoLevels {
+		t.Errorf("expected ErrNoLevels, got %v", err)
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	game, _ := NewGame(1, "SNG", assets.NewBuyIn(dollars, 10, 10), nil, 3, 1500, schedule, &seatstest.Notifiable{})
+	alice := newPlayer("alice", 10)
+	if err := game.Register(newPlayer("bob", 5)); err != errCannotAfford {
+		t.Errorf("expected the accounting error, got %v", err)
+	}
+	if err := game.Register(alice); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkBalance(t, alice, 0)
+	if err := game.Register(alice); err != ErrAlreadyRegistered {
+		t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+	}
+	if registered, total := game.Occupancy(); registered != 1 || total != 3 {
+		t.Errorf("expected occupancy 1/3, got %d/%d", registered, total)
+	}
+	if err := game.Unregister(alice); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkBalance(t, alice, 10)
+	if err := game.Unregister(alice); err != ErrNotRegistered {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+	if game.Status() != games.Registering || game.Table() != nil {
+		t.Errorf("expected the game to be registering")
+	}
+}
+
+// Finds the seat of a player by name.
+func seatOf(hand *hands.Hand, name string) seats.Seat {
+	for _, seat := range hand.Seats {
+		if seat.Player().(*player).name == name {
+			return seat
+		}
+	}
+	return nil
+}
+
+// Moves all the chips of the losers to the winner.
+func transfer(hand *hands.Hand, winner string, chips map[string]uint64) {
+	for name, amount := range chips {
+		_ = seatOf(hand, name).SubStack(amount)
+		_ = seatOf(hand, winner).AddStack(amount)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	prizes := assets.Prizes{*assets.NewPayment(dollars, 70), *assets.NewPayment(dollars, 30)}
+	game, _ := NewGame(1, "SNG", assets.NewBuyIn(dollars, 10, 10), prizes, 3, 1500, schedule, &seatstest.Notifiable{})
+	var blinds []forced.Blinds
+	done := make(chan bool)
+	game.Random = rand.New(rand.NewSource(1))
+	game.Variant = func(hand *hands.Hand, positions *button.Hand, levelBlinds forced.Blinds) {
+		blinds = append(blinds, levelBlinds)
+		switch hand.HandID {
+		case 1:
+			// alice loses 500 chips: she starts the next
+			// hand with less chips than bob.
+			transfer(hand, "carol", map[string]uint64{"alice": 500})
+		case 2:
+			// alice and bob are eliminated in the same
+			// hand: bob finishes higher.
+			transfer(hand, "carol", map[string]uint64{"alice": 1000, "bob": 1500})
+			close(done)
+		}
+	}
+	alice, bob, carol := newPlayer("alice", 10), newPlayer("bob", 10), newPlayer("carol", 10)
+	for _, player := range []*player{alice, bob, carol} {
+		if err := game.Register(player); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if game.Status() != games.Playing {
+		t.Errorf("expected the game to be playing")
+	}
+	if err := game.Register(newPlayer("dave", 10)); err != ErrNotRegistering {
+		t.Errorf("expected ErrNotRegistering, got %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the tournament to be played")
+	}
+	for game.Table().Running() {
+		time.Sleep(time.Millisecond)
+	}
+	if game.Status() != games.Destroyed {
+		t.Errorf("expected the game to be destroyed")
+	}
+	places := game.Places()
+	if len(places) != 3 || places[0] != carol || places[1] != bob || places[2] != alice {
+		t.Errorf("unexpected places: %v", places)
+	}
+	checkBalance(t, carol, 70)
+	checkBalance(t, bob, 30)
+	checkBalance(t, alice, 0)
+	if len(blinds) != 2 || blinds[0].Big != 20 || blinds[1].Big != 40 {
+		t.Errorf("unexpected blinds: %v", blinds)
+	}
+}
+
+func TestCancelled(t *testing.T) {
+	game, _ := NewGame(1, "SNG", assets.NewBuyIn(dollars, 10, 10), nil, 2, 1500, schedule, &seatstest.Notifiable{})
+	game.Variant = func(hand *hands.Hand, positions *button.Hand, blinds forced.Blinds) {
+		panic("broken variant")
+	}
+	alice, bob := newPlayer("alice", 10), newPlayer("bob", 10)
+	game.Register(alice)
+	game.Register(bob)
+	deadline := time.Now().Add(time.Second)
+	for game.Status() == games.Playing && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if game.Status() != games.Destroyed || game.Reason() != "broken variant" {
+		t.Fatalf("expected the game to be cancelled, got status %d and reason %v", game.Status(), game.Reason())
+	}
+	checkBalance(t, alice, 10)
+	checkBalance(t, bob, 10)
+	if game.Table().Occupied() != 0 {
+		t.Errorf("expected the seats to be released")
+	}
+}
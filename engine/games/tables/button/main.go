@@ -0,0 +1,275 @@
+package button
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+var ErrNotEnoughPlayers = errors.New("there are not enough players to play a hand")
+
+// The rule to move the button and the blinds when
+// players leave or sit out.
+type Mode uint8
+
+const (
+	// The big blind always moves to the next player,
+	// the small blind goes to the seat of the former
+	// big blind and the button to the seat of the
+	// former small blind, even if those seats are now
+	// empty (i.e. the small blind or the button may
+	// be dead). Nobody skips the big blind.
+	DeadButton Mode = iota
+	// The button always moves to the next player, and
+	// the blinds are the next two players. There are
+	// no dead blinds, but a player may skip a blind.
+	MovingButton
+)
+
+// The positions, and who posts what, in a hand.
+type Hand struct {
+	// The button seat, or nil if the button is dead.
+	Button seats.Seat
+	// The small blind seat, or nil if the small blind
+	// is dead. Heads-up, the button is the small blind.
+	Small seats.Seat
+	// The big blind seat.
+	Big seats.Seat
+	// The seats dealt in, in table order, starting
+	// from the left of the button.
+	DealtIn []seats.Seat
+	// The seats coming back to play that must post a
+	// missed small blind (as dead money).
+	DeadSmall []seats.Seat
+	// The seats coming back to play that must post a
+	// missed big blind (as a live bet).
+	LiveBig []seats.Seat
+	// Whether the hand is played heads-up. In that
+	// case the button acts first before the flop, and
+	// last after it.
+	HeadsUp bool
+}
+
+// Decides the button and blinds positions for each hand
+// over the seats of a table, and keeps track of the blinds
+// missed by the players sitting out. Players that missed
+// their blinds either wait for the big blind to reach them,
+// or (if they want to post) post their missed blinds to
+// play right away.
+type Manager struct {
+	mode   Mode
+	seats  []seats.Seat
+	button int
+	small  int
+	big    int
+}
+
+// Creates a new manager over the seats of a table, in
+// table order. The first hand will be played with the
+// button on the first seat able to play.
+func NewManager(mode Mode, tableSeats []seats.Seat) *Manager {
+	return &Manager{mode, tableSeats, -1, -1, -1}
+}
+
+// Gets the mode of this manager.
+func (manager *Manager) Mode() Mode {
+	return manager.mode
+}
+
+// Marks a newly sat player as owing the big blind, so it
+// either waits for the big blind or posts it to play. This
+// is not needed before the first hand of the table.
+func (manager *Manager) Sat(seat seats.Seat) {
+	if manager.big >= 0 {
+		seat.SetFlag(seats.MissedBig)
+	}
+}
+
+// Tells whether the seat at an index can play a hand: it
+// is occupied, has chips and is not sitting out.
+func (manager *Manager) canPlay(index int) bool {
+	seat := manager.seats[index]
+	return seat.Status() != seats.Free && seat.Stack() != 0 && seat.Flags()&seats.SitOut == 0
+}
+
+// Gets the index of the next seat, after the given one,
+// that can play a hand.
+func (manager *Manager) next(index int) int {
+	count := len(manager.seats)
+	for step := 1; step <= count; step++ {
+		candidate := (index + step) % count
+		if manager.canPlay(candidate) {
+			return candidate
+		}
+	}
+	return -1
+}
+
+// Gets the seat at an index, if it can play a hand.
+func (manager *Manager) playing(index int) seats.Seat {
+	if index >= 0 && manager.canPlay(index) {
+		return manager.seats[index]
+	}
+	return nil
+}
+
+// Tells whether an index is strictly between two others,
+// going around the table.
+func between(index, from, to, count int) bool {
+	return (index-from+count)%count != 0 && (index-from+count)%count < (to-from+count)%count
+}
+
+// Marks the missed blinds of the occupied seats that cannot
+// play, when the big blind moves past them.
+func (manager *Manager) markMissed(from, to int) {
+	count := len(manager.seats)
+	for index, seat := range manager.seats {
+		if seat.Status() != seats.Free && !manager.canPlay(index) && between(index, from, to, count) {
+			seat.SetFlag(seats.MissedSmall | seats.MissedBig)
+		}
+	}
+	if manager.mode == DeadButton && from >= 0 {
+		if seat := manager.seats[from]; seat.Status() != seats.Free && !manager.canPlay(from) {
+			seat.SetFlag(seats.MissedSmall)
+		}
+	}
+}
+
+// Moves the button and the blinds to their new positions.
+func (manager *Manager) move(headsUp bool) {
+	switch {
+	case manager.big < 0:
+		manager.button = manager.next(len(manager.seats) - 1)
+		if headsUp {
+			manager.small = manager.button
+		} else {
+			manager.small = manager.next(manager.button)
+		}
+		manager.big = manager.next(manager.small)
+	case headsUp:
+		// The former big blind, if it remains, will
+		// not post the big blind twice in a row.
+		manager.big = manager.next(manager.big)
+		manager.button = manager.next(manager.big)
+		manager.small = manager.button
+	case manager.mode == DeadButton && manager.small != manager.button:
+		manager.button = manager.small
+		manager.small = manager.big
+		manager.big = manager.next(manager.big)
+	default:
+		// Coming from heads-up in the dead button mode,
+		// the button and the small blind were the same,
+		// so the button moves as in the moving button
+		// mode.
+		manager.button = manager.next(manager.button)
+		manager.small = manager.next(manager.button)
+		manager.big = manager.next(manager.small)
+	}
+}
+
+// Counts the seats that can play a hand.
+func (manager *Manager) able() int {
+	count := 0
+	for index := range manager.seats {
+		if manager.canPlay(index) {
+			count++
+		}
+	}
+	return count
+}
+
+// Gets the current button seat, or nil if there is no
+// button yet (or it is dead).
+func (manager *Manager) Button() seats.Seat {
+	return manager.playing(manager.button)
+}
+
+// Gets the positions of a hand played with no blinds (e.g.
+// a stud game, in a mixed games rotation): the button does
+// not move, and every seat able to play is dealt in, from
+// the left of the button. The missed blinds are kept, to be
+// posted when the blinds are played again. Returns an error
+// if there are less than two players able to play.
+func (manager *Manager) Frozen() (*Hand, error) {
+	count := manager.able()
+	if count < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+	hand := &Hand{Button: manager.Button(), HeadsUp: count == 2}
+	total := len(manager.seats)
+	start := manager.button
+	if start < 0 {
+		start = total - 1
+	}
+	for step := 1; step <= total; step++ {
+		if index := (start + step) % total; manager.canPlay(index) {
+			hand.DealtIn = append(hand.DealtIn, manager.seats[index])
+		}
+	}
+	return hand, nil
+}
+
+// Decides the positions of the next hand, and who must post
+// missed blinds, clearing the missed blinds of the seats that
+// post them. Seats owing blinds and not wanting to post them
+// are not dealt in (nor are they when sitting between the
+// button and the big blind, in the dead button mode). Heads-up
+// there are no missed blinds to post. Returns an error if there
+// are less than two players able to play.
+func (manager *Manager) Next() (*Hand, error) {
+	count := manager.able()
+	if count < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	headsUp := count == 2
+	formerBig := manager.big
+	manager.move(headsUp)
+	if formerBig >= 0 {
+		manager.markMissed(formerBig, manager.big)
+	}
+	hand := &Hand{
+		Button:  manager.playing(manager.button),
+		Small:   manager.playing(manager.small),
+		Big:     manager.playing(manager.big),
+		HeadsUp: headsUp,
+	}
+	owed := seats.MissedSmall | seats.MissedBig | seats.WantsToPost
+	total := len(manager.seats)
+	for step := 1; step <= total; step++ {
+		index := (manager.button + step) % total
+		seat := manager.seats[index]
+		if !manager.canPlay(index) {
+			continue
+		}
+		flags := seat.Flags()
+		if seat == hand.Small || seat == hand.Big || headsUp || formerBig < 0 || flags&(owed^seats.WantsToPost) == 0 {
+			hand.DealtIn = append(hand.DealtIn, seat)
+		} else if flags&seats.WantsToPost == 0 ||
+			(manager.mode == DeadButton && between(index, manager.button, manager.big, total)) {
+			// The seat waits for the big blind.
+			continue
+		} else {
+			if flags&seats.MissedSmall != 0 {
+				hand.DeadSmall = append(hand.DeadSmall, seat)
+			}
+			if flags&seats.MissedBig != 0 {
+				hand.LiveBig = append(hand.LiveBig, seat)
+			}
+			hand.DealtIn = append(hand.DealtIn, seat)
+		}
+	}
+	if len(hand.DealtIn) < 2 {
+		// There would be no hand otherwise, so the
+		// waiting seats play without posting.
+		hand.DealtIn, hand.DeadSmall, hand.LiveBig = nil, nil, nil
+		for step := 1; step <= total; step++ {
+			if index := (manager.button + step) % total; manager.canPlay(index) {
+				hand.DealtIn = append(hand.DealtIn, manager.seats[index])
+			}
+		}
+	}
+	for _, seat := range hand.DealtIn {
+		seat.ClearFlag(owed)
+	}
+	return hand, nil
+}
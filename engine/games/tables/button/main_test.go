@@ -0,0 +1,160 @@
+package button
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+func next(t *testing.T, manager *Manager) *Hand {
+	hand, err := manager.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return hand
+}
+
+func testPositions(t *testing.T, label string, hand *Hand, button, small, big seats.Seat) {
+	if hand.Button != button || hand.Small != small || hand.Big != big {
+		t.Errorf("%s: unexpected positions", label)
+	}
+}
+
+func testSeats(t *testing.T, label string, actual []seats.Seat, expected ...seats.Seat) {
+	if len(actual) != len(expected) {
+		t.Errorf("%s: expected %d seats, got %d", label, len(expected), len(actual))
+		return
+	}
+	for index, seat := range expected {
+		if actual[index] != seat {
+			t.Errorf("%s: expected seat %d at index %d, got seat %d", label, seat.SeatID(), index,
+				actual[index].SeatID())
+		}
+	}
+}
+
+func TestNotEnoughPlayers(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000)
+	s[1].SetFlag(seats.SitOut)
+	if _, err := NewManager(DeadButton, s).Next(); err != ErrNotEnoughPlayers {
+		t.Errorf("expected ErrNotEnoughPlayers, got %v", err)
+	}
+}
+
+func TestDeadButtonMissedBlinds(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	manager := NewManager(DeadButton, s)
+	hand := next(t, manager)
+	testPositions(t, "first hand", hand, s[0], s[1], s[2])
+	testSeats(t, "first hand", hand.DealtIn, s[1], s[2], s[3], s[0])
+
+	s[3].SetFlag(seats.SitOut)
+	hand = next(t, manager)
+	testPositions(t, "second hand", hand, s[1], s[2], s[0])
+	testSeats(t, "second hand", hand.DealtIn, s[2], s[0], s[1])
+	if s[3].Flags() != seats.SitOut|seats.MissedSmall|seats.MissedBig {
+		t.Errorf("expected the fourth seat to miss both blinds")
+	}
+
+	// Back, but waiting for the big blind.
+	s[3].ClearFlag(seats.SitOut)
+	hand = next(t, manager)
+	testPositions(t, "third hand", hand, s[2], s[0], s[1])
+	testSeats(t, "third hand", hand.DealtIn, s[0], s[1], s[2])
+
+	// Posting both the missed blinds, to play now.
+	s[3].SetFlag(seats.WantsToPost)
+	hand = next(t, manager)
+	testPositions(t, "fourth hand", hand, s[0], s[1], s[2])
+	testSeats(t, "fourth hand", hand.DealtIn, s[1], s[2], s[3], s[0])
+	testSeats(t, "fourth hand dead small", hand.DeadSmall, s[3])
+	testSeats(t, "fourth hand live big", hand.LiveBig, s[3])
+	if s[3].Flags() != seats.Nothing {
+		t.Errorf("expected the missed blinds to be cleared")
+	}
+}
+
+func TestDeadButtonDeadSmallBlind(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	manager := NewManager(DeadButton, s)
+	next(t, manager)
+	s[2].Pop()
+	hand := next(t, manager)
+	testPositions(t, "second hand", hand, s[1], nil, s[3])
+	hand = next(t, manager)
+	testPositions(t, "third hand", hand, nil, s[3], s[0])
+	testSeats(t, "third hand", hand.DealtIn, s[3], s[0], s[1])
+}
+
+func TestWaitingForBigBlind(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	manager := NewManager(DeadButton, s)
+	next(t, manager)
+	s[0].Pop()
+	s[0].Sit(&seatstest.Player{}, 1000)
+	manager.Sat(s[0])
+	hand := next(t, manager)
+	testPositions(t, "second hand", hand, s[1], s[2], s[3])
+	testSeats(t, "second hand", hand.DealtIn, s[2], s[3], s[1])
+	hand = next(t, manager)
+	testPositions(t, "third hand", hand, s[2], s[3], s[0])
+	testSeats(t, "third hand", hand.DealtIn, s[3], s[0], s[1], s[2])
+}
+
+func TestHeadsUp(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	manager := NewManager(DeadButton, s)
+	hand := next(t, manager)
+	testPositions(t, "first hand", hand, s[0], s[1], s[2])
+	s[0].Pop()
+	// The former big blind does not post it again.
+	hand = next(t, manager)
+	testPositions(t, "second hand", hand, s[2], s[2], s[1])
+	if !hand.HeadsUp {
+		t.Errorf("expected the hand to be heads-up")
+	}
+	testSeats(t, "second hand", hand.DealtIn, s[1], s[2])
+	hand = next(t, manager)
+	testPositions(t, "third hand", hand, s[1], s[1], s[2])
+
+	// Back to three players.
+	s[0].Sit(&seatstest.Player{}, 1000)
+	hand = next(t, manager)
+	testPositions(t, "fourth hand", hand, s[2], s[0], s[1])
+	testSeats(t, "fourth hand", hand.DealtIn, s[0], s[1], s[2])
+}
+
+func TestMovingButton(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	manager := NewManager(MovingButton, s)
+	next(t, manager)
+	s[1].SetFlag(seats.SitOut)
+	hand := next(t, manager)
+	testPositions(t, "second hand", hand, s[2], s[3], s[0])
+	hand = next(t, manager)
+	testPositions(t, "third hand", hand, s[3], s[0], s[2])
+	if s[1].Flags()&seats.MissedBig == 0 {
+		t.Errorf("expected the second seat to miss the big blind")
+	}
+}
+
+func TestFrozen(t *testing.T) {
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	manager := NewManager(DeadButton, s)
+	hand, err := manager.Frozen()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hand.Button != nil || len(hand.DealtIn) != 4 || hand.DealtIn[0] != s[0] {
+		t.Errorf("expected everybody dealt in from the first seat, with no button")
+	}
+	next(t, manager)
+	s[2].SetFlag(seats.SitOut)
+	hand, _ = manager.Frozen()
+	if hand.Button != s[0] || len(hand.DealtIn) != 3 || hand.DealtIn[0] != s[1] || hand.DealtIn[1] != s[3] {
+		t.Errorf("expected the button to stay, and the seat sitting out not to be dealt in")
+	}
+	if manager.Button() != s[0] {
+		t.Errorf("expected the button not to move")
+	}
+}
@@ -1,6 +1,9 @@
 package environment
 
 import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
 	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
 	"github.com/luismasuelli/poker-go/engine/misc"
 )
@@ -39,6 +42,56 @@ func (broadcaster *Broadcaster) Notify(message interface{}) {
 	broadcaster.parent.Notify(message)
 }
 
+// Gets the parent notifiable, which also gets the
+// messages not intended for the seats and watchers
+// (e.g. the ones for accounting).
+func (broadcaster *Broadcaster) Parent() misc.Notifiable {
+	return broadcaster.parent
+}
+
+// Notifies a table message, wrapped in a game
+// message, to both sit players and watchers.
+func (broadcaster *Broadcaster) NotifyTable(gameID interface{}, tableID uint32, content interface{}) {
+	broadcaster.Notify(games.GameMessage{
+		GameID: gameID,
+		Content: tables.TableMessage{
+			TableID: tableID,
+			Content: content,
+		},
+	})
+}
+
+// Notifies a seat message, wrapped in table and
+// game messages, to both sit players and watchers.
+func (broadcaster *Broadcaster) NotifySeat(gameID interface{}, tableID uint32, seatID uint8, content interface{}) {
+	broadcaster.NotifyTable(gameID, tableID, messages.SeatMessage{
+		SeatID:  seatID,
+		Content: content,
+	})
+}
+
+// Notifies a seat message, wrapped in table and
+// game messages, only to the player sitting in
+// that seat (e.g. to tell them their cards).
+// Nothing is done if the seat is empty.
+func (broadcaster *Broadcaster) NotifyOwner(gameID interface{}, tableID uint32, seat seats.Seat, content interface{}) {
+	if player := seat.Player(); player != nil {
+		func() {
+			defer func() { recover() }()
+			player.Notify(games.GameMessage{
+				GameID: gameID,
+				Content: tables.TableMessage{
+					TableID: tableID,
+					Content: messages.SeatMessage{
+						SeatID:  seat.SeatID(),
+						Content: content,
+					},
+				},
+			})
+		}()
+	}
+}
+
 // Registers a new watcher. Returns false
 // if already registered, or nil.
 func (broadcaster *Broadcaster) Register(watcher misc.Notifiable) bool {
@@ -0,0 +1,186 @@
+package hands
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine"
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+var ErrInvalidDiscardIndex = errors.New("the index of a discarded card is invalid")
+var ErrRepeatedDiscardIndex = errors.New("the index of a discarded card is repeated")
+var ErrTooManyDiscards = errors.New("too many cards are discarded")
+var ErrWrongDiscardCount = errors.New("the number of discarded cards is wrong")
+
+// A discard of a player, in response to a request to
+// draw cards: the indices of the cards to replace. No
+// indices means the player stands pat.
+type Discard struct {
+	RequestID engine.RequestID
+	Indices   []int
+}
+
+// The input of a hand asking the players for their
+// discards, in draw games. Implementations must always
+// return a discard, even when the player does not respond
+// in time (e.g. standing pat by default).
+type DiscardInput interface {
+	Discard(hand *Hand, seat seats.Seat, max int) Discard
+}
+
+// A function can be used as the discard input of a hand.
+type DiscardInputFunc func(hand *Hand, seat seats.Seat, max int) Discard
+
+// Invokes the function.
+func (input DiscardInputFunc) Discard(hand *Hand, seat seats.Seat, max int) Discard {
+	return input(hand, seat, max)
+}
+
+// Validates the indices of the cards a seat discards: they
+// must be valid and not repeated, and at most max of them.
+func ValidateDiscard(seat seats.Seat, indices []int, max int) error {
+	if len(indices) > max {
+		return ErrTooManyDiscards
+	}
+	count := len(seat.Cards(true))
+	seen := map[int]bool{}
+	for _, index := range indices {
+		if index < 0 || index >= count {
+			return ErrInvalidDiscardIndex
+		} else if seen[index] {
+			return ErrRepeatedDiscardIndex
+		}
+		seen[index] = true
+	}
+	return nil
+}
+
+// Runs a draw round, asking the seats not folded in the given
+// order for their discards (up to max cards), and replacing
+// them. Rejected discards are notified to the owner of the
+// seat, which is asked again (after too many of them, the
+// seat stands pat).
+func (hand *Hand) Draw(order []seats.Seat, max int) {
+	for _, seat := range order {
+		if status := seat.Status(); status != seats.Active && status != seats.AllIn {
+			continue
+		}
+		for rejected := 0; ; rejected++ {
+			discard := Discard{}
+			if hand.Discards != nil && rejected < hand.maxRejections() {
+				discard = hand.Discards.Discard(hand, seat, max)
+			}
+			if err := ValidateDiscard(seat, discard.Indices, max); err != nil {
+				hand.Broadcaster.NotifyOwner(hand.GameID, hand.TableID, seat, messages.YourActionHasBeenRejected{
+					RequestID: discard.RequestID,
+					Reason:    err.Error(),
+				})
+				continue
+			}
+			hand.Replace(seat, discard.Indices)
+			break
+		}
+	}
+}
+
+// Gets the discard of a seat when its player does not
+// respond in time, in games where discarding is mandatory
+// (e.g. Pineapple): its last count cards.
+func DefaultDiscard(seat seats.Seat, count int) Discard {
+	held := len(seat.Cards(true))
+	indices := make([]int, 0, count)
+	for index := held - count; index < held; index++ {
+		if index >= 0 {
+			indices = append(indices, index)
+		}
+	}
+	return Discard{Indices: indices}
+}
+
+// Runs a discard round (e.g. in Pineapple), asking the seats
+// not folded in the given order for exactly count cards to
+// discard, which are not replaced. Rejected discards are
+// notified to the owner of the seat, which is asked again
+// (after too many of them, the default discard is used).
+// With no discard input, the default discard is used. After
+// the discards, the hands are tabled if no more betting is
+// possible.
+func (hand *Hand) Discard(order []seats.Seat, count int) {
+	for _, seat := range order {
+		if status := seat.Status(); status != seats.Active && status != seats.AllIn {
+			continue
+		}
+		for rejected := 0; ; rejected++ {
+			var discard Discard
+			if hand.Discards != nil && rejected < hand.maxRejections() {
+				discard = hand.Discards.Discard(hand, seat, count)
+			} else {
+				discard = DefaultDiscard(seat, count)
+			}
+			err := ValidateDiscard(seat, discard.Indices, count)
+			if err == nil && len(discard.Indices) != count {
+				err = ErrWrongDiscardCount
+			}
+			if err != nil {
+				hand.Broadcaster.NotifyOwner(hand.GameID, hand.TableID, seat, messages.YourActionHasBeenRejected{
+					RequestID: discard.RequestID,
+					Reason:    err.Error(),
+				})
+				continue
+			}
+			hand.Muck = append(hand.Muck, hand.give(seat, discard.Indices)...)
+			break
+		}
+	}
+	hand.Discarding = false
+	hand.table()
+}
+
+// Removes the cards of a seat, by their (valid) indices, and
+// returns them. Only the count is told to the whole table,
+// while the indices are told to the owner of the seat.
+func (hand *Hand) give(seat seats.Seat, indices []int) []cards.Card {
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatGaveCards{Count: len(indices)})
+	hand.Broadcaster.NotifyOwner(hand.GameID, hand.TableID, seat, messages.YouGaveCards{Indices: indices})
+	if len(indices) == 0 {
+		return nil
+	}
+	held := seat.Cards(true)
+	discarded := make([]cards.Card, len(indices))
+	for position, index := range indices {
+		discarded[position] = held[index]
+	}
+	seat.RemoveCards(indices)
+	return discarded
+}
+
+// Replaces the cards of a seat, by their (valid) indices.
+// Only the count is told to the whole table, while the
+// indices are told to the owner of the seat. Replacements
+// are dealt from the stub, which is reshuffled with the
+// muck when it runs short. The discards go to the muck
+// only after that, so they are never dealt back to the
+// same seat. If the stub and the muck together hold less
+// cards than needed (e.g. in triple draw, with many seats),
+// the seat only gets the cards left (if any), so it keeps
+// less cards than it had.
+func (hand *Hand) Replace(seat seats.Seat, indices []int) {
+	discarded := hand.give(seat, indices)
+	if len(discarded) == 0 {
+		return
+	}
+	if hand.Deck.Len() < len(indices) {
+		hand.Deck.Queue(hand.Muck)
+		hand.Muck = nil
+		hand.Shuffler.Shuffle(hand.Deck)
+	}
+	count := len(indices)
+	if left := hand.Deck.Len(); left < count {
+		count = left
+	}
+	if count > 0 {
+		hand.DealTo(seat, make([]bool, count)...)
+	}
+	hand.Muck = append(hand.Muck, discarded...)
+}
@@ -0,0 +1,405 @@
+package hands
+
+import (
+	"github.com/luismasuelli/poker-go/engine"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/oddchips"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/shufflers"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/collect"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/pot"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/rake"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"time"
+)
+
+// A decision of a player, in response to a request
+// to act in a betting round. The amount is only
+// meaningful for bets and raises.
+type Decision struct {
+	RequestID engine.RequestID
+	Action    actions.Action
+	Amount    uint64
+}
+
+// The input of a hand asks the players for their
+// decisions. Implementations must always return a
+// decision, even when the player does not respond
+// in time (e.g. checking or folding by default).
+type Input interface {
+	Act(hand *Hand, seat seats.Seat, options betting.Options) Decision
+}
+
+// A function can be used as the input of a hand.
+type InputFunc func(hand *Hand, seat seats.Seat, options betting.Options) Decision
+
+// Invokes the function.
+func (input InputFunc) Act(hand *Hand, seat seats.Seat, options betting.Options) Decision {
+	return input(hand, seat, options)
+}
+
+// The context of a hand being played in a table. Game
+// drivers (e.g. Hold'Em) use it to deal cards, run the
+// betting rounds and award the pots, while all of these
+// steps are notified through the broadcaster.
+type Hand struct {
+	GameID  interface{}
+	TableID uint32
+	HandID  uint64
+	// The seats dealt in, in table order, starting
+	// from the left of the button.
+	Seats []seats.Seat
+	// The seat ID of the button (0 if there is no
+	// button, e.g. in stud games, or it is dead).
+	Button      uint8
+	Broadcaster *environment.Broadcaster
+	Shuffler    shufflers.Shuffler
+	Input       Input
+	// The rejected decisions (or discards) allowed to
+	// a seat in a turn, before it checks or folds (or
+	// stands pat in draw games, or gives the default
+	// discard in Pineapple) (0 means 3).
+	MaxRejections int
+	// The input asking for the discards, in draw
+	// games (nil means every seat stands pat).
+	Discards DiscardInput
+	// The input asking whether to muck or show
+	// the cards (nil means always showing them
+	// at the showdown, unless auto-mucking, and
+	// never revealing them otherwise).
+	Shows ShowInput
+	// The input asking the seats involved in an
+	// all-in whether to run the rest of the board
+	// more than once, up to MaxRuns times (nil, or
+	// less than 2 runs, means running it once).
+	Runs      RunsInput
+	MaxRuns   uint8
+	Structure structures.Structure
+	// The policy for odd chips (nil means the
+	// showdown order).
+	OddChips oddchips.Policy
+	// The time to wait after each showdown.
+	Interval time.Duration
+	// The rake taken from the pots before they are
+	// awarded (nil means no rake), credited to the
+	// house in the asset of the table.
+	Rake  *rake.Rake
+	House players.Accounting
+	Asset assets.Asset
+	// The state of the hand.
+	Deck      cards.Deck
+	Community []cards.Card
+	Muck      []cards.Card
+	Pots      []*pots.Pot
+	// The boards (each one including the community
+	// cards dealt before the all-in), when the rest
+	// of the board was run more than once, or the
+	// two boards of a double-board pot.
+	Boards      [][]cards.Card
+	DoubleBoard bool
+	// Whether the seats still have to discard (e.g.
+	// in Pineapple): their hands are not tabled until
+	// they do, so the discards are never shown.
+	Discarding bool
+	// The last seat betting or raising in the last
+	// betting round (nil if everybody checked).
+	LastAggressor seats.Seat
+	// The seats that showed their cards, and whether
+	// the hands were tabled (i.e. all of them shown,
+	// since no more betting is possible).
+	shown  map[seats.Seat]bool
+	tabled bool
+	// Whether the seats were already asked to run
+	// the rest of the board more than once.
+	asked bool
+	// The last street bet, and whether the rake was
+	// already taken.
+	street uint8
+	raked  bool
+}
+
+// Starts the hand: copies and shuffles the deck, and
+// makes the seats dealt in active.
+func (hand *Hand) Start(template cards.Deck) {
+	hand.Deck = template.Copy()
+	hand.Shuffler.Shuffle(hand.Deck)
+	hand.Community = nil
+	hand.Muck = nil
+	hand.Pots = nil
+	hand.LastAggressor = nil
+	hand.shown = nil
+	hand.tabled = false
+	hand.Boards = nil
+	hand.DoubleBoard = false
+	hand.Discarding = false
+	hand.asked = false
+	hand.street = 0
+	hand.raked = false
+	for _, seat := range hand.Seats {
+		seat.SetStatus(seats.Active)
+	}
+}
+
+// Gets the seats that did not fold, in the order of
+// the dealt in seats.
+func (hand *Hand) Remaining() []seats.Seat {
+	result := make([]seats.Seat, 0, len(hand.Seats))
+	for _, seat := range hand.Seats {
+		if status := seat.Status(); status == seats.Active || status == seats.AllIn {
+			result = append(result, seat)
+		}
+	}
+	return result
+}
+
+// Tells whether more than one seat did not fold.
+func (hand *Hand) Contested() bool {
+	return len(hand.Remaining()) > 1
+}
+
+// Gets the seats dealt in, starting from the given index.
+func (hand *Hand) OrderFrom(index int) []seats.Seat {
+	count := len(hand.Seats)
+	result := make([]seats.Seat, count)
+	for offset := range result {
+		result[offset] = hand.Seats[(index+offset)%count]
+	}
+	return result
+}
+
+// Gets the seats dealt in, starting from the one after the
+// given seat (e.g. the big blind, before the flop).
+func (hand *Hand) OrderAfter(seat seats.Seat) []seats.Seat {
+	for index, candidate := range hand.Seats {
+		if candidate == seat {
+			return hand.OrderFrom(index + 1)
+		}
+	}
+	return hand.OrderFrom(0)
+}
+
+// Deals cards to a seat, one per given flag telling whether
+// the card is dealt face up. The cards are notified to the
+// whole table (only the face up ones are revealed) and to
+// the owner of the seat.
+func (hand *Hand) DealTo(seat seats.Seat, shown ...bool) {
+	dealt := hand.Deck.Deal(len(shown))
+	seatCards := make([]*seats.SeatCard, len(dealt))
+	public := make([]cards.Card, len(dealt))
+	for index, card := range dealt {
+		if shown[index] {
+			seatCards[index] = seats.NewSeatShownCard(card)
+			public[index] = card
+		} else {
+			seatCards[index] = seats.NewSeatCard(card)
+		}
+	}
+	seat.AddCards(seatCards)
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatDrewCards{Cards: public})
+	hand.Broadcaster.NotifyOwner(hand.GameID, hand.TableID, seat, messages.YouDrewCards{Cards: dealt, Shown: shown})
+}
+
+// Deals cards to each seat not folded, one at a time and
+// in order, until each seat got a card per given flag.
+func (hand *Hand) DealAround(shown ...bool) {
+	for _, flag := range shown {
+		for _, seat := range hand.Remaining() {
+			hand.DealTo(seat, flag)
+		}
+	}
+}
+
+// Burns a card.
+func (hand *Hand) Burn() {
+	hand.Muck = append(hand.Muck, hand.Deck.Deal(1)...)
+}
+
+// Burns a card and deals community cards, notifying them.
+func (hand *Hand) DealCommunity(count int) {
+	hand.Burn()
+	hand.RevealCommunity(count)
+}
+
+// Starts a double-board pot: the community cards are dealt
+// to two boards, and each half of the pots is awarded in one
+// of them. It must be invoked after starting the hand.
+func (hand *Hand) StartDoubleBoard() {
+	hand.DoubleBoard = true
+	hand.Boards = make([][]cards.Card, 2)
+}
+
+// Burns a card and deals community cards to each board of a
+// double-board pot, notifying them.
+func (hand *Hand) DealBoards(count int) {
+	for board := range hand.Boards {
+		hand.Burn()
+		dealt := hand.Deck.Deal(count)
+		hand.Boards[board] = append(hand.Boards[board], dealt...)
+		hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.CommunityCardsHaveBeenDealt{
+			HandID: hand.HandID,
+			Board:  uint8(board),
+			Cards:  dealt,
+		})
+	}
+}
+
+// Deals community cards with no burn, notifying them (e.g.
+// the single community card of stud games, when the deck
+// runs short).
+func (hand *Hand) RevealCommunity(count int) {
+	dealt := hand.Deck.Deal(count)
+	hand.Community = append(hand.Community, dealt...)
+	hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.CommunityCardsHaveBeenDealt{
+		HandID: hand.HandID,
+		Cards:  dealt,
+	})
+}
+
+// Runs a betting round, asking the seats in the given order
+// for their decisions, and collects the bets into the pots
+// when it is over. Rejected decisions are notified to the
+// owner of the seat, which is asked again (after too many
+// of them, the seat checks or folds). The seats that
+// opened the betting with a forced bet (e.g. the bring-in)
+// may be given, so they do not get the option to act again.
+func (hand *Hand) Bet(street uint8, order []seats.Seat, opened ...seats.Seat) {
+	if street > hand.street {
+		hand.street = street
+	}
+	round := betting.NewRound(hand.GameID, hand.TableID, street, order, hand.Pots, hand.Structure, hand.Broadcaster)
+	for _, seat := range opened {
+		round.Opened(seat)
+	}
+	maxRejections := hand.maxRejections()
+	rejected := 0
+	for !round.Done() {
+		seat := round.ToAct()
+		options := round.Options(seat)
+		var decision Decision
+		if rejected < maxRejections {
+			decision = hand.Input.Act(hand, seat, options)
+		} else if options.CanCheck {
+			decision = Decision{Action: actions.Check}
+		} else {
+			decision = Decision{Action: actions.Fold}
+		}
+		if err := round.Act(decision.RequestID, seat, decision.Action, decision.Amount); err != nil {
+			hand.Broadcaster.NotifyOwner(hand.GameID, hand.TableID, seat, messages.YourActionHasBeenRejected{
+				RequestID: decision.RequestID,
+				Reason:    err.Error(),
+			})
+			rejected++
+		} else {
+			rejected = 0
+		}
+	}
+	hand.LastAggressor = round.LastAggressor()
+	hand.Pots = collect.CollectPots(hand.GameID, hand.TableID, hand.Seats, hand.Pots, hand.Broadcaster)
+	hand.table()
+}
+
+// Gets the rejected decisions allowed to a seat in a turn.
+func (hand *Hand) maxRejections() int {
+	if hand.MaxRejections <= 0 {
+		return 3
+	}
+	return hand.MaxRejections
+}
+
+// Shows the cards of a seat to the whole table.
+func (hand *Hand) Show(seat seats.Seat) {
+	hand.markShown(seat)
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatHasShownCards{
+		Cards: seat.Cards(true),
+	})
+}
+
+// Shows only the cards of a seat making its best hand (e.g.
+// the best subset, in Badugi), as flagged by an evaluator:
+// the i-th bit tells whether the i-th card is used. The
+// other cards are notified as nil.
+func (hand *Hand) ShowBest(seat seats.Seat, best uint32) {
+	hand.markShown(seat)
+	shown := seat.Cards(true)
+	for index := range shown {
+		if best&(1<<index) == 0 {
+			shown[index] = nil
+		}
+	}
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatHasShownCards{
+		Cards: shown,
+	})
+}
+
+// Splits the pots among the showdown modes, and awards them
+// according to the podiums.
+func (hand *Hand) Award(podiums showdowns.Podiums) {
+	hand.takeRake()
+	potSets := showdowns.SplitPots(hand.Pots, podiums)
+	pot.AwardPots(hand.GameID, hand.TableID, hand.HandID, 0, podiums, potSets, hand.OddChips, hand.Button,
+		hand.Broadcaster, hand.Interval)
+}
+
+// Awards all the pots to the only seat that did not fold,
+// with no showdown.
+func (hand *Hand) AwardUncontested() {
+	remaining := hand.Remaining()
+	if len(remaining) != 1 {
+		return
+	}
+	hand.takeRake()
+	pot.AwardModePots(hand.GameID, hand.TableID, hand.HandID, 0, showdowns.Standard, hand.Pots,
+		showdowns.Podium{{remaining[0]}}, hand.OddChips, hand.Button, hand.Broadcaster)
+}
+
+// Takes the rake from the pots, once per hand and before
+// they are awarded. The flop counts as seen when community
+// cards were dealt (in any board), or a betting round after
+// the first one was played (in games with no flop). If the
+// house could not be credited, no rake is taken, and this
+// is announced to the table. Otherwise, the rake taken is
+// attributed to the seats dealt in, and sent to the parent
+// of the broadcaster (e.g. for rakeback and loyalty).
+func (hand *Hand) takeRake() {
+	if hand.Rake == nil || hand.raked {
+		return
+	}
+	hand.raked = true
+	flopSeen := hand.street > 0 || len(hand.Community) > 0 || len(hand.Boards) > 0
+	taken, err := hand.Rake.Take(hand.GameID, hand.TableID, hand.HandID, hand.Pots, uint8(len(hand.Seats)), flopSeen,
+		hand.House, hand.Asset, hand.Broadcaster)
+	if err != nil {
+		hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.RakeHasFailed{
+			HandID: hand.HandID,
+			Reason: err.Error(),
+		})
+		return
+	}
+	rake.Attribute(hand.GameID, hand.TableID, hand.HandID, hand.Pots, taken, hand.Seats, hand.Broadcaster.Parent())
+}
+
+// Finishes the hand: the seats that did not show their cards
+// may reveal some of them, then the cards of the seats go to
+// the muck, and the seats dealt in wait for the next hand.
+func (hand *Hand) Finish() {
+	for _, seat := range hand.Seats {
+		hand.reveal(seat)
+	}
+	for _, seat := range hand.Seats {
+		hand.Muck = append(hand.Muck, seat.Cards(true)...)
+		seat.RemoveCards([]int{-1})
+		if seat.Status() != seats.Free {
+			seat.SetStatus(seats.Waiting)
+		}
+	}
+	hand.Pots = nil
+}
@@ -0,0 +1,616 @@
+package hands
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/cards/french"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	decks "github.com/luismasuelli/poker-go/engine/games/rules/french"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/betting"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/rake"
+	"testing"
+)
+
+// Keeps the deck as it is.
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(deck cards.Deck) {}
+
+func TestRank(t *testing.T) {
+	s := seatstest.MakeSeats(4)
+	powers := map[seats.Seat]uint64{s[0]: 10, s[1]: 30, s[2]: 10, s[3]: 20}
+	podium := Rank(s, powers, false)
+	if len(podium) != 3 || len(podium[0]) != 1 || podium[0][0] != s[1] || podium[1][0] != s[3] ||
+		len(podium[2]) != 2 || podium[2][0] != s[0] || podium[2][1] != s[2] {
+		t.Errorf("unexpected high podium: %v", podium)
+	}
+	podium = Rank([]seats.Seat{s[2], s[0], s[1]}, powers, true)
+	if len(podium) != 2 || len(podium[0]) != 2 || podium[0][0] != s[2] || podium[0][1] != s[0] || podium[1][0] != s[1] {
+		t.Errorf("unexpected low podium: %v", podium)
+	}
+}
+
+func TestOrderAfter(t *testing.T) {
+	s := seatstest.MakeSeats(3)
+	hand := &Hand{Seats: s}
+	order := hand.OrderAfter(s[1])
+	if order[0] != s[2] || order[1] != s[0] || order[2] != s[1] {
+		t.Errorf("expected the order to start after the second seat")
+	}
+}
+
+func TestValidateDiscard(t *testing.T) {
+	seat := seats.NewBaseSeat(1)
+	seat.Sit(&seatstest.Player{}, 1000)
+	seat.AddCards([]*seats.SeatCard{
+		seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3), seats.NewSeatCard(french.C4),
+		seats.NewSeatCard(french.C5), seats.NewSeatCard(french.C7),
+	})
+	if err := ValidateDiscard(seat, nil, 5); err != nil {
+		t.Errorf("expected standing pat to be valid: %s", err)
+	}
+	if err := ValidateDiscard(seat, []int{4, 0}, 5); err != nil {
+		t.Errorf("expected discarding two cards to be valid: %s", err)
+	}
+	if err := ValidateDiscard(seat, []int{5}, 5); err != ErrInvalidDiscardIndex {
+		t.Errorf("expected an invalid index, got %v", err)
+	}
+	if err := ValidateDiscard(seat, []int{-1}, 5); err != ErrInvalidDiscardIndex {
+		t.Errorf("expected an invalid index, got %v", err)
+	}
+	if err := ValidateDiscard(seat, []int{1, 1}, 5); err != ErrRepeatedDiscardIndex {
+		t.Errorf("expected a repeated index, got %v", err)
+	}
+	if err := ValidateDiscard(seat, []int{0, 1, 2, 3}, 3); err != ErrTooManyDiscards {
+		t.Errorf("expected too many discards, got %v", err)
+	}
+}
+
+func TestTooManyRejections(t *testing.T) {
+	for _, test := range []struct {
+		blind  uint64
+		asked  int
+		status seats.Status
+	}{
+		// Nothing to call: both seats check.
+		{0, 6, seats.Active},
+		// Facing the blind: the first seat folds.
+		{10, 3, seats.Folded},
+	} {
+		s := seatstest.MakeSeats(2)
+		for _, seat := range s {
+			seat.Sit(&seatstest.Player{}, 1000)
+			seat.SetStatus(seats.Active)
+		}
+		s[1].SubStack(test.blind)
+		s[1].AddPot(test.blind)
+		asked := 0
+		hand := &Hand{
+			Seats:       s,
+			Broadcaster: environment.NewBroadcaster(s, &seatstest.Notifiable{}),
+			Structure:   structures.NoLimit{BigBlind: 10},
+			Input: InputFunc(func(hand *Hand, seat seats.Seat, options betting.Options) Decision {
+				// Always a raise below the minimum.
+				asked++
+				return Decision{Action: actions.Raise, Amount: 1}
+			}),
+		}
+		hand.Bet(0, s)
+		if asked != test.asked || s[0].Status() != test.status {
+			t.Errorf("expected %d decisions and the status %d, got %d and %d", test.asked, test.status, asked,
+				s[0].Status())
+		}
+	}
+}
+
+func TestReplaceReshufflesTheMuck(t *testing.T) {
+	seat := seats.NewBaseSeat(1)
+	seat.Sit(&seatstest.Player{}, 1000)
+	seat.AddCards([]*seats.SeatCard{
+		seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3), seats.NewSeatCard(french.C4),
+		seats.NewSeatCard(french.C5), seats.NewSeatCard(french.C7),
+	})
+	hand := &Hand{
+		Seats:       []seats.Seat{seat},
+		Broadcaster: environment.NewBroadcaster([]seats.Seat{seat}, &seatstest.Notifiable{}),
+		Shuffler:    unshuffled{},
+		Deck:        decks.NewDeck(french.SA),
+		Muck:        []cards.Card{french.HA, french.DA},
+	}
+	hand.Replace(seat, []int{0, 4})
+	held := seat.Cards(true)
+	if len(held) != 5 || held[0] != french.C3 || held[2] != french.C5 {
+		t.Errorf("expected the kept cards to stay in order: %v", held)
+	}
+	for _, card := range held[3:] {
+		if card == french.C2 || card == french.C7 {
+			t.Errorf("expected the discards not to be dealt back: %v", held)
+		}
+	}
+	if hand.Deck.Len() != 1 || len(hand.Muck) != 2 || hand.Muck[0] != french.C2 || hand.Muck[1] != french.C7 {
+		t.Errorf("expected the discards to be in the muck, and one card in the stub: %v", hand.Muck)
+	}
+}
+
+func TestReplaceWithTooFewCardsLeft(t *testing.T) {
+	// The seat keeps less cards than it had: only the one
+	// in the muck, or none at all.
+	for _, muck := range [][]cards.Card{{french.HA}, nil} {
+		seat := seats.NewBaseSeat(1)
+		seat.Sit(&seatstest.Player{}, 1000)
+		seat.AddCards([]*seats.SeatCard{
+			seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3), seats.NewSeatCard(french.C4),
+		})
+		hand := &Hand{
+			Seats:       []seats.Seat{seat},
+			Broadcaster: environment.NewBroadcaster([]seats.Seat{seat}, &seatstest.Notifiable{}),
+			Shuffler:    unshuffled{},
+			Deck:        decks.NewDeck(),
+			Muck:        muck,
+		}
+		hand.Replace(seat, []int{0, 1, 2})
+		if held := seat.Cards(true); len(held) != len(muck) || (len(muck) > 0 && held[0] != muck[0]) {
+			t.Errorf("expected only the cards left to be dealt: %v", held)
+		}
+		if hand.Deck.Len() != 0 || len(hand.Muck) != 3 {
+			t.Errorf("expected the discards to be in the muck: %v", hand.Muck)
+		}
+	}
+}
+
+// Two seats with a card each, the first one covered by
+// the second one, betting with the given actions.
+func makeCovered(recorder *showdownRecorder, decisions ...Decision) ([]seats.Seat, *Hand) {
+	s, hand := makeShowdown(recorder, []french.Card{french.C2}, []french.Card{french.SA})
+	s[0].SubStack(900)
+	hand.Pots = nil
+	hand.Structure = structures.NoLimit{BigBlind: 10}
+	hand.Input = InputFunc(func(hand *Hand, seat seats.Seat, options betting.Options) Decision {
+		decision := decisions[0]
+		decisions = decisions[1:]
+		return decision
+	})
+	return s, hand
+}
+
+func TestTableCoveredAllIn(t *testing.T) {
+	// The second seat calls the all-in of the first one,
+	// with chips behind: both hands are tabled.
+	recorder := &showdownRecorder{cards: map[uint8][]cards.Card{}}
+	s, hand := makeCovered(recorder, Decision{Action: actions.AllIn}, Decision{Action: actions.Call})
+	hand.Bet(0, s)
+	if !hand.tabled || len(recorder.shown) != 2 {
+		t.Errorf("expected the hands to be tabled, got %v shown", recorder.shown)
+	}
+
+	// Two seats with chips behind may still bet: the
+	// hands are not tabled.
+	recorder = &showdownRecorder{cards: map[uint8][]cards.Card{}}
+	s, hand = makeCovered(recorder, Decision{Action: actions.Check}, Decision{Action: actions.Check})
+	s[0].AddStack(900)
+	hand.Bet(0, s)
+	if hand.tabled || len(recorder.shown) != 0 {
+		t.Errorf("expected the hands not to be tabled, got %v shown", recorder.shown)
+	}
+}
+
+// Keeps the cards shown, and the seats mucking, in order.
+type showdownRecorder struct {
+	shown  []uint8
+	cards  map[uint8][]cards.Card
+	mucked []uint8
+}
+
+func (recorder *showdownRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		switch seatContent := seatMessage.Content.(type) {
+		case messages.SeatHasShownCards:
+			recorder.shown = append(recorder.shown, seatMessage.SeatID)
+			recorder.cards[seatMessage.SeatID] = seatContent.Cards
+		case messages.SeatHasMuckedCards:
+			recorder.mucked = append(recorder.mucked, seatMessage.SeatID)
+		}
+	}
+}
+
+// Never mucks, and reveals the given cards.
+type revealing []int
+
+func (indices revealing) Muck(hand *Hand, seat seats.Seat) bool    { return false }
+func (indices revealing) Reveal(hand *Hand, seat seats.Seat) []int { return indices }
+
+// Gives the seats the given cards, and a hand among them.
+func makeShowdown(recorder *showdownRecorder, held ...[]french.Card) ([]seats.Seat, *Hand) {
+	s := seatstest.MakeSeats(len(held))
+	for index, seat := range s {
+		seat.Sit(&seatstest.Player{}, 1000)
+		seat.SetStatus(seats.Active)
+		for _, card := range held[index] {
+			seat.AddCards([]*seats.SeatCard{seats.NewSeatCard(card)})
+		}
+	}
+	return s, &Hand{
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, recorder),
+		Pots:        []*pots.Pot{pots.NewPot(400, s)},
+	}
+}
+
+// The power of a hand is the value of its first card.
+func firstCard(hand, community []cards.Card) (uint32, uint64) {
+	return 1, uint64(hand[0].(french.Card))
+}
+
+func TestShowdownOrder(t *testing.T) {
+	s, hand := makeShowdown(&showdownRecorder{}, nil, nil, nil, nil)
+	s[2].SetStatus(seats.Folded)
+	if order := hand.ShowdownOrder(); len(order) != 3 || order[0] != s[0] || order[2] != s[3] {
+		t.Errorf("expected the first seat to show first, when nobody bet: %v", order)
+	}
+	hand.LastAggressor = s[1]
+	if order := hand.ShowdownOrder(); len(order) != 3 || order[0] != s[1] || order[1] != s[3] || order[2] != s[0] {
+		t.Errorf("expected the last aggressor to show first: %v", order)
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	// The last aggressor shows first. The all-in seat shows
+	// even if it cannot win, the fourth seat shows the best
+	// hand, and the first one (auto-mucking) mucks.
+	recorder := &showdownRecorder{cards: map[uint8][]cards.Card{}}
+	s, hand := makeShowdown(recorder, []french.Card{french.C3}, []french.Card{french.C5},
+		[]french.Card{french.C2}, []french.Card{french.C9})
+	s[2].SetStatus(seats.AllIn)
+	if err := s[0].SetFlag(seats.AutoMuck); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	hand.LastAggressor = s[1]
+	hand.Showdown(Evaluation{High: firstCard})
+	if len(recorder.shown) != 3 || recorder.shown[0] != 2 || recorder.shown[1] != 3 || recorder.shown[2] != 4 {
+		t.Errorf("unexpected shown hands: %v", recorder.shown)
+	}
+	if len(recorder.mucked) != 1 || recorder.mucked[0] != 1 || s[0].Status() != seats.Folded {
+		t.Errorf("expected the first seat to muck: %v", recorder.mucked)
+	}
+	if s[3].Stack() != 1400 {
+		t.Errorf("expected the fourth seat to win the pot, got %d", s[3].Stack())
+	}
+}
+
+func TestRevealAfterFolding(t *testing.T) {
+	recorder := &showdownRecorder{cards: map[uint8][]cards.Card{}}
+	s, hand := makeShowdown(recorder, []french.Card{french.C2, french.C3}, []french.Card{french.C5, french.C7})
+	s[0].SetStatus(seats.Folded)
+	hand.Shows = revealing{1, 1, 5}
+	hand.Show(s[1])
+	hand.Finish()
+	if shown := recorder.cards[1]; len(shown) != 2 || shown[0] != nil || shown[1] != french.C3 {
+		t.Errorf("expected the folded seat to reveal only its second card: %v", shown)
+	}
+	if len(recorder.shown) != 2 {
+		t.Errorf("expected the shown seat not to be asked again: %v", recorder.shown)
+	}
+}
+
+// Keeps the boards of the dealt community cards, and the
+// boards and seats of the chips won.
+type boardsRecorder struct {
+	dealt []uint8
+	won   map[uint8]uint8
+}
+
+func (recorder *boardsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	switch tableContent := content.(type) {
+	case tables.CommunityCardsHaveBeenDealt:
+		recorder.dealt = append(recorder.dealt, tableContent.Board)
+	case messages.SeatMessage:
+		if won, ok := tableContent.Content.(messages.PlayerWonChips); ok {
+			recorder.won[won.Board] = tableContent.SeatID
+		}
+	}
+}
+
+// The power of a hand is the distance between its first
+// card and the last community card (the lower, the better).
+func closest(hand, community []cards.Card) (uint32, uint64) {
+	first, last := int(hand[0].(french.Card)), int(community[len(community)-1].(french.Card))
+	if first > last {
+		return 1, uint64(first - last)
+	}
+	return 1, uint64(last - first)
+}
+
+// Two all-in seats, with a tabled hand and a pot of 201.
+func makeAllIn(recorder *boardsRecorder, runs ...uint8) ([]seats.Seat, *Hand) {
+	s := seatstest.MakeSeats(2)
+	for index, card := range []french.Card{french.C2, french.SA} {
+		s[index].Sit(&seatstest.Player{}, 1000)
+		s[index].SetStatus(seats.AllIn)
+		s[index].AddCards([]*seats.SeatCard{seats.NewSeatCard(card)})
+	}
+	return s, &Hand{
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, recorder),
+		Runs: RunsInputFunc(func(hand *Hand, seat seats.Seat, max uint8) uint8 {
+			return runs[seat.SeatID()-1]
+		}),
+		MaxRuns:   3,
+		Deck:      decks.NewDeck(french.D4, french.D5, french.SK, french.DA, french.C3, french.H2),
+		Community: []cards.Card{french.D7},
+		Pots:      []*pots.Pot{pots.NewPot(201, s)},
+		tabled:    true,
+	}
+}
+
+func TestRunItTwice(t *testing.T) {
+	// The seats agree to run the river twice: the first
+	// seat wins the first board (3 of clubs), and the
+	// second seat wins the second one (king of spades).
+	recorder := &boardsRecorder{won: map[uint8]uint8{}}
+	s, hand := makeAllIn(recorder, 3, 2)
+	if !hand.RunOut([]int{1}) {
+		t.Fatalf("expected the board to be run more than once")
+	}
+	if len(hand.Boards) != 2 || hand.Deck.Len() != 2 || len(recorder.dealt) != 2 || recorder.dealt[1] != 1 {
+		t.Errorf("expected two boards to be dealt: %v", hand.Boards)
+	}
+	hand.Showdown(Evaluation{Low: closest})
+	if s[0].Stack() != 1101 || s[1].Stack() != 1100 {
+		t.Errorf("expected the pot to be divided, the odd chip going to the first board: %d, %d",
+			s[0].Stack(), s[1].Stack())
+	}
+	if recorder.won[0] != 1 || recorder.won[1] != 2 {
+		t.Errorf("unexpected winners by board: %v", recorder.won)
+	}
+	if hand.RunOut([]int{1}) {
+		t.Errorf("expected the seats to be asked only once")
+	}
+}
+
+func TestRunItTwiceWhenCovered(t *testing.T) {
+	// The all-in is called by a seat with chips behind:
+	// both seats are asked, and the river is run twice.
+	s, hand := makeCovered(&showdownRecorder{cards: map[uint8][]cards.Card{}},
+		Decision{Action: actions.AllIn}, Decision{Action: actions.Call})
+	asked := 0
+	hand.Runs = RunsInputFunc(func(hand *Hand, seat seats.Seat, max uint8) uint8 {
+		asked++
+		return max
+	})
+	hand.MaxRuns = 2
+	hand.Deck = decks.NewDeck(french.D4, french.D5, french.SK, french.DA)
+	hand.Bet(0, s)
+	if !hand.RunOut([]int{1}) || asked != 2 || len(hand.Boards) != 2 {
+		t.Errorf("expected the board to be run twice, got %d boards and %d seats asked", len(hand.Boards), asked)
+	}
+}
+
+func TestRunItOnce(t *testing.T) {
+	recorder := &boardsRecorder{won: map[uint8]uint8{}}
+	_, hand := makeAllIn(recorder, 1, 3)
+	if hand.RunOut([]int{1}) || hand.Boards != nil || hand.Deck.Len() != 6 || len(recorder.dealt) != 0 {
+		t.Errorf("expected nothing to be dealt when a seat does not agree")
+	}
+	_, hand = makeAllIn(recorder, 3, 3)
+	hand.tabled = false
+	if hand.RunOut([]int{1}) {
+		t.Errorf("expected the board not to be run more than once while betting is possible")
+	}
+}
+
+// A house refusing the rake.
+type refusingHouse struct {
+	seatstest.Player
+}
+
+func (house *refusingHouse) Add(asset assets.Asset, amount uint64) error {
+	return errors.New("account closed")
+}
+
+// Keeps the failed and attributed rakes.
+type rakeRecorder []interface{}
+
+func (recorder *rakeRecorder) Notify(message interface{}) {
+	switch content := message.(games.GameMessage).Content.(tables.TableMessage).Content.(type) {
+	case tables.RakeHasFailed, tables.RakeHasBeenAttributed:
+		*recorder = append(*recorder, content)
+	}
+}
+
+func TestRake(t *testing.T) {
+	for _, test := range []struct {
+		street    uint8
+		community []cards.Card
+		boards    [][]cards.Card
+		rake      uint64
+	}{
+		// No flop, no drop.
+		{0, nil, nil, 0},
+		{1, nil, nil, 20},
+		// The board was run out after an all-in before
+		// the flop, once or more than once.
+		{0, []cards.Card{french.C2, french.C3, french.C4}, nil, 20},
+		{0, nil, [][]cards.Card{{french.C2}, {french.C3}}, 20},
+	} {
+		s, hand := makeShowdown(&showdownRecorder{}, nil, nil)
+		s[1].SetStatus(seats.Folded)
+		house := &seatstest.Player{}
+		hand.Rake = rake.NewRake(500, 0, true)
+		hand.House = house
+		hand.street = test.street
+		hand.Community = test.community
+		hand.Boards = test.boards
+		hand.AwardUncontested()
+		if house.Credited != test.rake || s[0].Stack() != 1400-test.rake {
+			t.Errorf("expected a rake of %d, got %d (stack: %d)", test.rake, house.Credited, s[0].Stack())
+		}
+	}
+}
+
+func TestFailedRake(t *testing.T) {
+	var recorder rakeRecorder
+	s, hand := makeShowdown(&showdownRecorder{}, nil, nil)
+	hand.Broadcaster = environment.NewBroadcaster(s, &recorder)
+	hand.HandID = 7
+	s[1].SetStatus(seats.Folded)
+	hand.Rake = rake.NewRake(500, 0, false)
+	hand.House = &refusingHouse{}
+	hand.AwardUncontested()
+	if s[0].Stack() != 1400 {
+		t.Errorf("expected no rake to be taken, got a stack of %d", s[0].Stack())
+	}
+	if failed, ok := recorder[0].(tables.RakeHasFailed); len(recorder) != 1 || !ok || failed.HandID != 7 ||
+		failed.Reason != "account closed" {
+		t.Errorf("expected the failed rake to be announced: %v", recorder)
+	}
+}
+
+func TestRakeAttribution(t *testing.T) {
+	var recorder rakeRecorder
+	s, hand := makeShowdown(&showdownRecorder{}, nil, nil)
+	hand.Broadcaster = environment.NewBroadcaster(s, &recorder)
+	hand.HandID = 7
+	s[1].SetStatus(seats.Folded)
+	hand.Rake = rake.NewRake(500, 0, false)
+	hand.House = &seatstest.Player{}
+	hand.AwardUncontested()
+	attributed, ok := recorder[0].(tables.RakeHasBeenAttributed)
+	if len(recorder) != 1 || !ok || attributed.HandID != 7 || len(attributed.Shares) != 2 {
+		t.Fatalf("expected the rake to be attributed: %v", recorder)
+	}
+	for _, share := range attributed.Shares {
+		if share.Contributed != 10 || share.Dealt != 10 {
+			t.Errorf("expected 10 chips attributed to seat %d, got %d/%d", share.SeatID, share.Contributed,
+				share.Dealt)
+		}
+	}
+}
+
+func TestDoubleBoard(t *testing.T) {
+	// The first seat wins the first board (3 of clubs),
+	// and the second seat wins the second one (king of
+	// spades). Each board gets half of the pot.
+	recorder := &boardsRecorder{won: map[uint8]uint8{}}
+	s, hand := makeAllIn(recorder, 3, 3)
+	hand.StartDoubleBoard()
+	hand.DealBoards(1)
+	if hand.Boards[0][0] != french.C3 || hand.Boards[1][0] != french.SK || len(recorder.dealt) != 2 {
+		t.Errorf("expected a card to be dealt in each board: %v", hand.Boards)
+	}
+	if hand.RunOut([]int{1}) {
+		t.Errorf("expected double-board pots not to be run more than once")
+	}
+	hand.Showdown(Evaluation{High: func(hand, community []cards.Card) (uint32, uint64) {
+		_, distance := closest(hand, community)
+		return 1, 100 - distance
+	}})
+	if s[0].Stack() != 1101 || s[1].Stack() != 1100 {
+		t.Errorf("expected each board to get half of the pot: %d, %d", s[0].Stack(), s[1].Stack())
+	}
+}
+
+// Keeps the counts of the cards given, told to the table.
+type discardsRecorder []int
+
+func (recorder *discardsRecorder) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if seatMessage, ok := content.(messages.SeatMessage); ok {
+		if gave, ok := seatMessage.Content.(messages.SeatGaveCards); ok {
+			*recorder = append(*recorder, gave.Count)
+		}
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	s := seatstest.MakeSeats(2)
+	for _, seat := range s {
+		seat.Sit(&seatstest.Player{}, 1000)
+		seat.SetStatus(seats.Active)
+		seat.AddCards([]*seats.SeatCard{
+			seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3), seats.NewSeatCard(french.C4),
+		})
+	}
+	var recorder discardsRecorder
+	asked := 0
+	hand := &Hand{
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, &recorder),
+		Discards: DiscardInputFunc(func(hand *Hand, seat seats.Seat, max int) Discard {
+			asked++
+			if seat == s[0] && asked == 1 {
+				// Standing pat is not allowed.
+				return Discard{RequestID: 1}
+			} else if seat == s[0] {
+				return Discard{Indices: []int{1}}
+			}
+			// The player did not respond in time.
+			return DefaultDiscard(seat, max)
+		}),
+	}
+	hand.Discard(hand.Seats, 1)
+	if asked != 3 || len(recorder) != 2 || recorder[0] != 1 || recorder[1] != 1 {
+		t.Errorf("expected a rejection, and a card given by each seat: %d asked, %v", asked, recorder)
+	}
+	if held := s[0].Cards(true); len(held) != 2 || held[0] != french.C2 || held[1] != french.C4 {
+		t.Errorf("expected the first seat to keep the deuce and the four: %v", held)
+	}
+	if held := s[1].Cards(true); len(held) != 2 || held[0] != french.C2 || held[1] != french.C3 {
+		t.Errorf("expected the second seat to give its last card by default: %v", held)
+	}
+	if len(hand.Muck) != 2 || hand.Muck[0] != french.C3 || hand.Muck[1] != french.C4 {
+		t.Errorf("expected the discards to be in the muck: %v", hand.Muck)
+	}
+}
+
+func TestDrawWithTooManyRejections(t *testing.T) {
+	s := seatstest.MakeActive(1000)
+	s[0].AddCards([]*seats.SeatCard{seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3)})
+	var recorder discardsRecorder
+	asked := 0
+	hand := &Hand{
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, &recorder),
+		Discards: DiscardInputFunc(func(hand *Hand, seat seats.Seat, max int) Discard {
+			// A card that does not exist.
+			asked++
+			return Discard{Indices: []int{9}}
+		}),
+		MaxRejections: 2,
+	}
+	hand.Draw(s, 2)
+	if asked != 2 || len(recorder) != 1 || recorder[0] != 0 || len(s[0].Cards(true)) != 2 {
+		t.Errorf("expected the seat to stand pat after 2 rejections: %d asked, %v", asked, recorder)
+	}
+}
+
+func TestDiscardWithTooManyRejections(t *testing.T) {
+	s := seatstest.MakeActive(1000)
+	s[0].AddCards([]*seats.SeatCard{
+		seats.NewSeatCard(french.C2), seats.NewSeatCard(french.C3), seats.NewSeatCard(french.C4),
+	})
+	asked := 0
+	hand := &Hand{
+		Seats:       s,
+		Broadcaster: environment.NewBroadcaster(s, &seatstest.Notifiable{}),
+		Discards: DiscardInputFunc(func(hand *Hand, seat seats.Seat, max int) Discard {
+			// Standing pat is not allowed.
+			asked++
+			return Discard{}
+		}),
+	}
+	hand.Discard(s, 1)
+	if held := s[0].Cards(true); asked != 3 || len(held) != 2 || held[1] != french.C3 {
+		t.Errorf("expected the default discard after 3 rejections: %d asked, %v", asked, held)
+	}
+}
@@ -0,0 +1,104 @@
+package hands
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"sort"
+)
+
+// Evaluates the best hand of a seat, given its cards
+// and the community cards (e.g. card7/high.Power).
+type Evaluator func(hand, community []cards.Card) (uint32, uint64)
+
+// Ranks the given seats by the power of their hands into
+// a podium. For high games the greater power wins, and for
+// low games (lowerWins) the lower power wins. Tied seats
+// keep the given (showdown) order in their position.
+func Rank(order []seats.Seat, powers map[seats.Seat]uint64, lowerWins bool) showdowns.Podium {
+	ranked := make([]seats.Seat, 0, len(order))
+	for _, seat := range order {
+		if _, ok := powers[seat]; ok {
+			ranked = append(ranked, seat)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if lowerWins {
+			return powers[ranked[i]] < powers[ranked[j]]
+		}
+		return powers[ranked[i]] > powers[ranked[j]]
+	})
+	podium := showdowns.Podium{}
+	for index, seat := range ranked {
+		if index == 0 || powers[seat] != powers[ranked[index-1]] {
+			podium = append(podium, showdowns.PodiumPosition{})
+		}
+		podium[len(podium)-1] = append(podium[len(podium)-1], seat)
+	}
+	return podium
+}
+
+// The way the hands are evaluated at the showdown. High
+// games only have a high evaluator, and lowball games (e.g.
+// Razz) only have a low one: they play a standard showdown.
+// Hi/lo games have both, and a rule telling whether a low
+// power qualifies (nil means every low hand qualifies).
+// Games with a single evaluator may show only the cards
+// making the best hand (e.g. the best subset, in Badugi).
+type Evaluation struct {
+	High      Evaluator
+	Low       Evaluator
+	Qualifies func(power uint64) bool
+	BestOnly  bool
+}
+
+// Builds the podiums of a showdown, given the seats showing
+// their hands in order. For hi/lo games, the low podium is
+// nil when no hand qualifies for low.
+func (evaluation Evaluation) Podiums(order []seats.Seat, community []cards.Card) showdowns.Podiums {
+	highPowers := map[seats.Seat]uint64{}
+	lowPowers := map[seats.Seat]uint64{}
+	for _, seat := range order {
+		if evaluation.High != nil {
+			_, highPowers[seat] = evaluation.High(seat.Cards(true), community)
+		}
+		if evaluation.Low != nil {
+			_, power := evaluation.Low(seat.Cards(true), community)
+			if evaluation.High == nil || evaluation.Qualifies == nil || evaluation.Qualifies(power) {
+				lowPowers[seat] = power
+			}
+		}
+	}
+	if evaluation.Low == nil {
+		return showdowns.Podiums{showdowns.Standard: Rank(order, highPowers, false)}
+	} else if evaluation.High == nil {
+		return showdowns.Podiums{showdowns.Standard: Rank(order, lowPowers, true)}
+	}
+	var lowPodium showdowns.Podium
+	if len(lowPowers) != 0 {
+		lowPodium = Rank(order, lowPowers, true)
+	}
+	return showdowns.Podiums{showdowns.High: Rank(order, highPowers, false), showdowns.Low: lowPodium}
+}
+
+// Builds the podiums of a double-board showdown: one per
+// board, each one ranked as in a standard showdown. Only
+// games with a single evaluator (i.e. not hi/lo) can be
+// played with two boards.
+func (evaluation Evaluation) BoardPodiums(order []seats.Seat, boards [][]cards.Card) showdowns.Podiums {
+	return showdowns.Podiums{
+		showdowns.FirstBoard:  evaluation.Podiums(order, boards[0])[showdowns.Standard],
+		showdowns.SecondBoard: evaluation.Podiums(order, boards[1])[showdowns.Standard],
+	}
+}
+
+// Gets the flags of the cards making the best hand of a
+// seat, as told by the single evaluator.
+func (evaluation Evaluation) best(seat seats.Seat, community []cards.Card) uint32 {
+	evaluator := evaluation.High
+	if evaluator == nil {
+		evaluator = evaluation.Low
+	}
+	best, _ := evaluator(seat.Cards(true), community)
+	return best
+}
@@ -0,0 +1,104 @@
+package hands
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/cards"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/pot"
+)
+
+// The input of a hand asking the seats involved in an
+// all-in how many times (up to max) they agree to run
+// the rest of the board. Implementations must always
+// return an answer, even when the player does not respond
+// in time (e.g. 1, running it once, by default).
+type RunsInput interface {
+	Runs(hand *Hand, seat seats.Seat, max uint8) uint8
+}
+
+// A function can be used as the runs input of a hand.
+type RunsInputFunc func(hand *Hand, seat seats.Seat, max uint8) uint8
+
+// Invokes the function.
+func (input RunsInputFunc) Runs(hand *Hand, seat seats.Seat, max uint8) uint8 {
+	return input(hand, seat, max)
+}
+
+// Runs the rest of the board (i.e. the given streets) more
+// than once, when no more betting is possible and all the
+// seats not folded agree. The seats are asked once in the
+// hand, and the number of runs is the lowest one they agree
+// to (also limited by the cards left in the deck). Each run
+// is dealt and notified as a different board. Returns false,
+// dealing nothing, when the board is to be run once (this is
+// always the case in double-board pots).
+func (hand *Hand) RunOut(streets []int) bool {
+	if hand.asked || hand.DoubleBoard || !hand.tabled || hand.Runs == nil || hand.MaxRuns < 2 {
+		return false
+	}
+	hand.asked = true
+	need := 0
+	for _, count := range streets {
+		need += count + 1
+	}
+	runs := hand.MaxRuns
+	if need == 0 {
+		return false
+	} else if available := hand.Deck.Len() / need; available < int(runs) {
+		runs = uint8(available)
+	}
+	if runs < 2 {
+		return false
+	}
+	for _, seat := range hand.Remaining() {
+		if agreed := hand.Runs.Runs(hand, seat, runs); agreed < runs {
+			runs = agreed
+		}
+	}
+	if runs < 2 {
+		return false
+	}
+	hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.RunsHaveBeenAgreed{
+		HandID: hand.HandID,
+		Runs:   runs,
+	})
+	hand.Boards = make([][]cards.Card, runs)
+	for board := range hand.Boards {
+		dealt := append([]cards.Card{}, hand.Community...)
+		for _, count := range streets {
+			hand.Burn()
+			street := hand.Deck.Deal(count)
+			dealt = append(dealt, street...)
+			hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.CommunityCardsHaveBeenDealt{
+				HandID: hand.HandID,
+				Board:  uint8(board),
+				Cards:  street,
+			})
+		}
+		hand.Boards[board] = dealt
+	}
+	return true
+}
+
+// Divides each pot among the boards (the odd chips going to
+// the first ones), and awards each part according to the
+// podiums of the shown hands in that board.
+func (hand *Hand) awardBoards(evaluation Evaluation, shown []seats.Seat) {
+	hand.takeRake()
+	divided := make([][]*pots.Pot, len(hand.Pots))
+	for index, collected := range hand.Pots {
+		divided[index] = collected.Divide(len(hand.Boards))
+	}
+	for board, community := range hand.Boards {
+		boardPots := make([]*pots.Pot, len(divided))
+		for index, parts := range divided {
+			boardPots[index] = parts[board]
+		}
+		podiums := evaluation.Podiums(shown, community)
+		potSets := showdowns.SplitPots(boardPots, podiums)
+		pot.AwardPots(hand.GameID, hand.TableID, hand.HandID, uint8(board), podiums, potSets, hand.OddChips,
+			hand.Button, hand.Broadcaster, hand.Interval)
+	}
+}
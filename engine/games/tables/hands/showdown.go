@@ -0,0 +1,196 @@
+package hands
+
+import (
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+// The input of a hand asking the players whether to muck
+// or show their cards. Implementations must always return
+// an answer, even when the player does not respond in time
+// (e.g. mucking, and revealing nothing, by default).
+type ShowInput interface {
+	// Asks a seat that cannot win any pot at the showdown
+	// whether to muck its cards (true) or show them.
+	Muck(hand *Hand, seat seats.Seat) bool
+	// Asks a seat that did not show its cards (e.g. it
+	// folded, or won with no showdown) for the indices of
+	// the cards to reveal, if any.
+	Reveal(hand *Hand, seat seats.Seat) []int
+}
+
+// Marks a seat as having shown its cards.
+func (hand *Hand) markShown(seat seats.Seat) {
+	if hand.shown == nil {
+		hand.shown = map[seats.Seat]bool{}
+	}
+	hand.shown[seat] = true
+}
+
+// Tables the hands of the seats not folded, face up, when
+// no more betting is possible (i.e. at most one of them is
+// not all-in, e.g. the seat calling a shorter all-in), and
+// the seats do not have to discard anymore. This happens
+// once in a hand.
+func (hand *Hand) table() {
+	if hand.tabled || hand.Discarding || !hand.Contested() {
+		return
+	}
+	active := 0
+	for _, seat := range hand.Remaining() {
+		if seat.Status() == seats.Active {
+			active++
+		}
+	}
+	if active > 1 {
+		return
+	}
+	hand.tabled = true
+	for _, seat := range hand.Remaining() {
+		hand.Show(seat)
+	}
+}
+
+// Gets the order to show the hands at the showdown: from the
+// last aggressor of the last betting round or, if everybody
+// checked, from the first seat left of the button (i.e. the
+// first dealt in). Only the seats not folded are included.
+func (hand *Hand) ShowdownOrder() []seats.Seat {
+	order := hand.Seats
+	if hand.LastAggressor != nil {
+		for index, seat := range hand.Seats {
+			if seat == hand.LastAggressor {
+				order = hand.OrderFrom(index)
+				break
+			}
+		}
+	}
+	result := make([]seats.Seat, 0, len(order))
+	for _, seat := range order {
+		if status := seat.Status(); status == seats.Active || status == seats.AllIn {
+			result = append(result, seat)
+		}
+	}
+	return result
+}
+
+// Tells whether a seat may win (or split) any pot it is
+// involved in, against the seats that already showed.
+func (hand *Hand) canWin(evaluation Evaluation, seat seats.Seat, shown []seats.Seat) bool {
+	for _, pot := range hand.Pots {
+		if !pot.Involves(seat) {
+			continue
+		}
+		contenders := make([]seats.Seat, 0, len(shown)+1)
+		for _, other := range shown {
+			if pot.Involves(other) {
+				contenders = append(contenders, other)
+			}
+		}
+		contenders = append(contenders, seat)
+		for _, podium := range hand.podiums(evaluation, contenders) {
+			if len(podium) == 0 {
+				continue
+			}
+			for _, winner := range podium[0] {
+				if winner == seat {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Tells whether a seat mucks its losing cards: without
+// being asked, if it auto-mucks, or asking the input.
+func (hand *Hand) mucks(seat seats.Seat) bool {
+	if seat.Flags()&seats.AutoMuck != 0 {
+		return true
+	} else if hand.Shows != nil {
+		return hand.Shows.Muck(hand, seat)
+	}
+	return false
+}
+
+// Mucks the cards of a seat at the showdown: it leaves the
+// hand (and the pots) without showing them.
+func (hand *Hand) MuckCards(seat seats.Seat) {
+	seat.SetStatus(seats.Folded)
+	for _, pot := range hand.Pots {
+		pot.SeatHasLeft(seat)
+	}
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatHasMuckedCards{})
+}
+
+// Runs the showdown among the seats not folded, in order,
+// and awards the pots. All-in seats (which were tabled) and
+// the seats that may win a pot, against the ones that showed
+// before them, show their cards. The other ones may muck.
+// The order of the shown hands breaks the ties in the podium
+// positions. When the board was run more than once, each pot
+// is divided among the boards, and awarded in each of them.
+// In double-board pots, each half is awarded in one board.
+func (hand *Hand) Showdown(evaluation Evaluation) {
+	var shown []seats.Seat
+	for _, seat := range hand.ShowdownOrder() {
+		if hand.tabled || seat.Status() == seats.AllIn || hand.canWin(evaluation, seat, shown) || !hand.mucks(seat) {
+			if hand.shown[seat] {
+				// Already tabled.
+			} else if evaluation.BestOnly {
+				hand.ShowBest(seat, evaluation.best(seat, hand.Community))
+			} else {
+				hand.Show(seat)
+			}
+			shown = append(shown, seat)
+		} else {
+			hand.MuckCards(seat)
+		}
+	}
+	if !hand.DoubleBoard && len(hand.Boards) > 1 {
+		hand.awardBoards(evaluation, shown)
+	} else {
+		hand.Award(hand.podiums(evaluation, shown))
+	}
+}
+
+// Builds the podiums of the given seats, in the community
+// cards or, in double-board pots, in each board.
+func (hand *Hand) podiums(evaluation Evaluation, order []seats.Seat) showdowns.Podiums {
+	if hand.DoubleBoard {
+		return evaluation.BoardPodiums(order, hand.Boards)
+	}
+	return evaluation.Podiums(order, hand.Community)
+}
+
+// Asks a seat that did not show its cards for the ones to
+// reveal, and shows them (the other ones being nil). Invalid
+// or repeated indices are ignored.
+func (hand *Hand) reveal(seat seats.Seat) {
+	if hand.Shows == nil || hand.shown[seat] || seat.Status() == seats.Free {
+		return
+	}
+	held := seat.Cards(true)
+	if len(held) == 0 {
+		return
+	}
+	revealed := make([]bool, len(held))
+	some := false
+	for _, index := range hand.Shows.Reveal(hand, seat) {
+		if index >= 0 && index < len(held) {
+			revealed[index] = true
+			some = true
+		}
+	}
+	if !some {
+		return
+	}
+	for index := range held {
+		if !revealed[index] {
+			held[index] = nil
+		}
+	}
+	hand.markShown(seat)
+	hand.Broadcaster.NotifySeat(hand.GameID, hand.TableID, seat.SeatID(), messages.SeatHasShownCards{Cards: held})
+}
@@ -0,0 +1,220 @@
+package tables
+
+import (
+	"github.com/luismasuelli/poker-go/engine"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/misc"
+	"github.com/luismasuelli/poker-go/support/types/sync"
+	. "sync"
+)
+
+// The dealer of a table plays each hand: it decides the
+// positions and the betting structure, and runs the game
+// driver (e.g. a rotation of mixed games). It returns an
+// error when the hand cannot be played.
+type Dealer interface {
+	Play(hand *hands.Hand) error
+}
+
+// A function can be used as the dealer of a table.
+type DealerFunc func(hand *hands.Hand) error
+
+// Invokes the function.
+func (dealer DealerFunc) Play(hand *hands.Hand) error {
+	return dealer(hand)
+}
+
+// A table of a game owns its seats, the broadcaster of its
+// messages (to the players sitting, the watchers, and the
+// game) and the hand cycle: a gated loop playing hands while
+// enough players are able to play. External parties use the
+// gate to hold the table between hands (e.g. to rebalance the
+// tables of a tournament, or by an administrator).
+type Table struct {
+	GameID interface{}
+	// The dealer playing the hands.
+	Dealer Dealer
+	// The hand settings (e.g. the inputs, the shuffler
+	// and the odd chips policy). Its IDs and broadcaster
+	// are set by the table for each hand, and its seats
+	// by the dealer.
+	Template hands.Hand
+	// The minimum number of players able to play (0
+	// means 2).
+	MinPlayers int
+	// Invoked before each hand, once the gate is passed
+	// (e.g. to seat or release the players who joined or
+	// left during the previous hand). The hand is skipped
+	// if not enough players remain able to play.
+	BeforeHand func(table *Table)
+	// Invoked after each hand, in the hand cycle (e.g.
+	// to raise the blinds, or to pay the eliminated
+	// players).
+	AfterHand func(table *Table, hand *hands.Hand)
+	// Invoked when the hand cycle ends, with the reason:
+	// nil when there are not enough players or the table
+	// was stopped, the error of the dealer, or the value
+	// of a panic.
+	Stopped func(table *Table, reason interface{})
+
+	id          engine.TableID
+	seats       []seats.Seat
+	broadcaster *environment.Broadcaster
+	gate        *sync.Gate
+	loop        *sync.GatedLoop
+	handID      uint64
+	stopping    bool
+	reason      interface{}
+	mutex       Mutex
+}
+
+// Creates a new table with the given number of empty seats
+// (their IDs start from 1), and a broadcaster notifying its
+// messages to the game (the parent) as well. The dealer must
+// be set before starting it.
+func NewTable(gameID interface{}, id engine.TableID, count int, parent misc.Notifiable) *Table {
+	tableSeats := make([]seats.Seat, count)
+	for index := range tableSeats {
+		tableSeats[index] = seats.NewBaseSeat(uint8(index + 1))
+	}
+	return &Table{
+		GameID:      gameID,
+		id:          id,
+		seats:       tableSeats,
+		broadcaster: environment.NewBroadcaster(tableSeats, parent),
+		gate:        sync.NewGate(),
+	}
+}
+
+// The ID of the table.
+func (table *Table) ID() engine.TableID {
+	return table.id
+}
+
+// The seats of the table.
+func (table *Table) Seats() []seats.Seat {
+	return table.seats
+}
+
+// The broadcaster of the table.
+func (table *Table) Broadcaster() *environment.Broadcaster {
+	return table.broadcaster
+}
+
+// The gate of the hand cycle. While it has witnesses, the
+// next hand waits to be played.
+func (table *Table) Gate() *sync.Gate {
+	return table.gate
+}
+
+// Counts the seats having a player.
+func (table *Table) Occupied() int {
+	count := 0
+	for _, seat := range table.seats {
+		if seat.Player() != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Counts the players able to play: sitting, not sitting
+// out, and having chips.
+func (table *Table) Able() int {
+	count := 0
+	for _, seat := range table.seats {
+		if seat.Player() != nil && seat.Flags()&seats.SitOut == 0 && seat.Stack() != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Tells whether enough players are able to play.
+func (table *Table) enough() bool {
+	min := table.MinPlayers
+	if min == 0 {
+		min = 2
+	}
+	return table.Able() >= min
+}
+
+// Tells whether the hand cycle is running.
+func (table *Table) Running() bool {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+	return table.loop != nil
+}
+
+// Starts the hand cycle, if it is not running and enough
+// players are able to play (e.g. after a player sits). The
+// cycle ends when there are not enough players anymore, the
+// table is stopped, or the dealer fails. Returns whether the
+// cycle was started.
+func (table *Table) Start() bool {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+	if table.loop != nil || !table.enough() {
+		return false
+	}
+	table.stopping = false
+	table.reason = nil
+	table.loop = sync.NewGatedOn(table.gate, nil, table.while, table.play, table.after)
+	return table.loop.Run()
+}
+
+// Stops the hand cycle after the current hand.
+func (table *Table) Stop() {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+	table.stopping = true
+}
+
+// Tells whether the next hand is to be played.
+func (table *Table) while() bool {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+	return !table.stopping && table.enough()
+}
+
+// Plays the next hand, with the table settings.
+func (table *Table) play() {
+	if table.BeforeHand != nil {
+		table.BeforeHand(table)
+		if !table.enough() {
+			return
+		}
+	}
+	table.handID++
+	hand := table.Template
+	hand.GameID = table.GameID
+	hand.TableID = uint32(table.id)
+	hand.HandID = table.handID
+	hand.Broadcaster = table.broadcaster
+	if err := table.Dealer.Play(&hand); err != nil {
+		table.mutex.Lock()
+		table.stopping = true
+		table.reason = err
+		table.mutex.Unlock()
+		return
+	}
+	if table.AfterHand != nil {
+		table.AfterHand(table, &hand)
+	}
+}
+
+// Ends the hand cycle, so it can be started again.
+func (table *Table) after(panicked interface{}) {
+	table.mutex.Lock()
+	table.loop = nil
+	reason := table.reason
+	if panicked != nil {
+		reason = panicked
+	}
+	table.mutex.Unlock()
+	if table.Stopped != nil {
+		table.Stopped(table, reason)
+	}
+}
@@ -0,0 +1,135 @@
+package tables
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+	"time"
+)
+
+// Creates a table with the given number of seats, and
+// players in the first ones. The hands are sent to the
+// returned channel, and the reason to stop to the other
+// one.
+func makeTable(t *testing.T, count, sitting int) (*Table, chan *hands.Hand, chan interface{}) {
+	table := NewTable(1, 1, count, &seatstest.Notifiable{})
+	for _, seat := range table.Seats()[:sitting] {
+		if err := seat.Sit(&seatstest.Player{}, 1000); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	played := make(chan *hands.Hand, 100)
+	stopped := make(chan interface{}, 1)
+	table.Dealer = DealerFunc(func(hand *hands.Hand) error {
+		played <- hand
+		return nil
+	})
+	table.Stopped = func(table *Table, reason interface{}) {
+		stopped <- reason
+	}
+	return table, played, stopped
+}
+
+func waitStopped(t *testing.T, stopped chan interface{}) interface{} {
+	select {
+	case reason := <-stopped:
+		return reason
+	case <-time.After(time.Second):
+		t.Fatalf("expected the hand cycle to stop")
+		return nil
+	}
+}
+
+func TestNotEnoughPlayers(t *testing.T) {
+	table, _, _ := makeTable(t, 6, 1)
+	if table.Start() || table.Running() {
+		t.Errorf("expected the hand cycle not to start with one player")
+	}
+	if table.Occupied() != 1 || table.Able() != 1 {
+		t.Errorf("expected one seat to be occupied")
+	}
+}
+
+func TestHandCycle(t *testing.T) {
+	table, played, stopped := makeTable(t, 6, 3)
+	table.AfterHand = func(table *Table, hand *hands.Hand) {
+		if hand.HandID == 3 {
+			// The third player sits out, and the
+			// cycle goes on with two of them.
+			_ = table.Seats()[2].SetFlag(seats.SitOut)
+		} else if hand.HandID == 5 {
+			_ = table.Seats()[1].SetFlag(seats.SitOut)
+		}
+	}
+	if !table.Start() {
+		t.Fatalf("expected the hand cycle to start")
+	}
+	if table.Start() {
+		t.Errorf("expected the hand cycle not to start twice")
+	}
+	if reason := waitStopped(t, stopped); reason != nil {
+		t.Errorf("unexpected reason: %v", reason)
+	}
+	if len(played) != 5 || table.Running() {
+		t.Errorf("expected 5 hands to be played, got %d", len(played))
+	}
+	for index := 1; index <= 5; index++ {
+		if hand := <-played; hand.HandID != uint64(index) || hand.TableID != 1 || hand.Broadcaster != table.Broadcaster() {
+			t.Errorf("unexpected hand: %+v", hand)
+		}
+	}
+	_ = table.Seats()[1].ClearFlag(seats.SitOut)
+	table.Dealer = DealerFunc(func(hand *hands.Hand) error {
+		return errors.New("no dealer")
+	})
+	if !table.Start() {
+		t.Fatalf("expected the hand cycle to start again")
+	}
+	if reason, ok := waitStopped(t, stopped).(error); !ok || reason.Error() != "no dealer" {
+		t.Errorf("expected the dealer error to stop the cycle, got %v", reason)
+	}
+}
+
+func TestGate(t *testing.T) {
+	table, played, stopped := makeTable(t, 6, 2)
+	table.AfterHand = func(table *Table, hand *hands.Hand) {
+		table.Stop()
+	}
+	table.Gate().Enter("rebalance")
+	if !table.Start() {
+		t.Fatalf("expected the hand cycle to start")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(played) != 0 {
+		t.Errorf("expected no hands while the gate is held")
+	}
+	table.Gate().Leave("rebalance")
+	waitStopped(t, stopped)
+	if len(played) != 1 {
+		t.Errorf("expected one hand after the gate is released, got %d", len(played))
+	}
+}
+
+func TestBeforeHand(t *testing.T) {
+	table, played, stopped := makeTable(t, 6, 2)
+	before := 0
+	table.BeforeHand = func(table *Table) {
+		before++
+		if before == 3 {
+			// A player leaves between hands: the third
+			// hand is not played.
+			table.Seats()[1].Pop()
+		}
+	}
+	if !table.Start() {
+		t.Fatalf("expected the hand cycle to start")
+	}
+	if reason := waitStopped(t, stopped); reason != nil {
+		t.Errorf("unexpected reason: %v", reason)
+	}
+	if before != 3 || len(played) != 2 {
+		t.Errorf("expected 2 hands to be played, got %d", len(played))
+	}
+}
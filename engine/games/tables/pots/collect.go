@@ -0,0 +1,255 @@
+package pots
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"sort"
+)
+
+// Tells whether a pot can still receive chips from
+// the current betting round. A pot becomes closed
+// when at least one of its involved seats went all-in
+// in a previous round (i.e. it is all-in and did not
+// put any chips in the current round).
+func isClosed(pot *Pot, contributions map[seats.Seat]uint64) bool {
+	for seat := range pot.seats {
+		if seat.Status() == seats.AllIn && contributions[seat] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Tells whether there is at least one pot, among the
+// existing and the new ones, that can be won by more
+// than one seat (not folded).
+func isContested(current []*Pot, layers []*Pot) bool {
+	for _, pots := range [][]*Pot{current, layers} {
+		for _, pot := range pots {
+			count := 0
+			for seat := range pot.seats {
+				if seat.Status() != seats.Folded {
+					count++
+				}
+			}
+			if count > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Gives chips back to a seat's stack. If the seat
+// was all-in, it becomes active again since it now
+// has chips in its stack.
+func giveBack(seat seats.Seat, chips uint64) {
+	seat.AddStack(chips)
+	if seat.Status() == seats.AllIn {
+		seat.SetStatus(seats.Active)
+	}
+}
+
+// Adds all the chips, contributions and involved
+// seats of a pot into another pot.
+func (pot *Pot) merge(other *Pot) {
+	pot.amount += other.amount
+	for seat, chips := range other.contributions {
+		pot.contributions[seat] += chips
+	}
+	for seat := range other.seats {
+		pot.seats[seat] = true
+	}
+}
+
+// Collects all the seats' pots (i.e. the bets of the
+// current round) into the given table pots, and then
+// resets the seats' pots. This function is meant to
+// be called at the end of every betting round.
+//
+// First, if the greatest bet was made by only one
+// seat (not folded), the part of it that no other
+// seat matched is not called, and goes back to the
+// stack of that seat.
+//
+// Then, chips are collected by levels: one level for
+// each distinct amount put by an all-in seat, and a
+// final level for the greatest amount put by any seat.
+// Each level becomes a pot that can be won by the
+// seats (not folded) that reached it, so the main pot
+// and the side pots are created accordingly. The
+// lowest level is added to the last existing pot,
+// unless it is closed because a seat involved in it
+// went all-in in a previous round.
+//
+// Levels that no seat can win (i.e. the chips there
+// were put only by seats that folded) are dead money
+// and are added to the level below. Side pots that
+// only one seat could win are never created: their
+// chips (including the dead money of folded seats
+// in that level) also go back to that seat's stack.
+//
+// The given seats must be in table order, and folded
+// seats are removed from all the resulting pots. The
+// seat that got chips back (if any) is returned with
+// the amount of chips it got back.
+func Collect(current []*Pot, tableSeats []seats.Seat) ([]*Pot, seats.Seat, uint64) {
+	contributions := map[seats.Seat]uint64{}
+	top, second := uint64(0), uint64(0)
+	var topSeat seats.Seat
+	for _, seat := range tableSeats {
+		chips := seat.Pot()
+		if chips == 0 {
+			continue
+		}
+		contributions[seat] = chips
+		if chips > top {
+			top, second, topSeat = chips, top, seat
+		} else if chips > second {
+			second = chips
+		}
+	}
+	if len(contributions) == 0 {
+		return current, nil, 0
+	}
+
+	// Return the uncalled part of the greatest bet.
+	var returnedSeat seats.Seat
+	returned := uint64(0)
+	if top > second && topSeat.Status() != seats.Folded {
+		returnedSeat, returned = topSeat, top-second
+		giveBack(topSeat, returned)
+		contributions[topSeat] = second
+		top = second
+	}
+
+	levels := make([]uint64, 0)
+	known := map[uint64]bool{}
+	for _, seat := range tableSeats {
+		chips := contributions[seat]
+		if _, ok := known[chips]; !ok && chips != 0 && seat.Status() == seats.AllIn {
+			known[chips] = true
+			levels = append(levels, chips)
+		}
+	}
+	if _, ok := known[top]; !ok {
+		levels = append(levels, top)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i] < levels[j]
+	})
+
+	// Build one pot per level, merging the dead
+	// money levels into the ones below.
+	layers := make([]*Pot, 0, len(levels))
+	previous := uint64(0)
+	for _, level := range levels {
+		layer := &Pot{0, map[seats.Seat]bool{}, map[seats.Seat]uint64{}}
+		for _, seat := range tableSeats {
+			chips := contributions[seat]
+			if chips <= previous {
+				continue
+			}
+			if chips > level {
+				chips = level
+			}
+			layer.amount += chips - previous
+			layer.contributions[seat] += chips - previous
+			if chips == level && seat.Status() != seats.Folded {
+				layer.seats[seat] = true
+			}
+		}
+		previous = level
+		if len(layer.seats) == 0 && len(layers) != 0 {
+			layers[len(layers)-1].merge(layer)
+		} else {
+			layers = append(layers, layer)
+		}
+	}
+
+	// The topmost levels that only one seat can win
+	// go back to that seat, unless there is no pot
+	// at all that can be won by more than one seat
+	// (in that case, the seat wins the hand and the
+	// levels are kept as pots to be awarded).
+	if isContested(current, layers) {
+		for count := len(layers); count != 0 && len(layers[count-1].seats) == 1; count-- {
+			layer := layers[count-1]
+			for seat := range layer.seats {
+				returnedSeat = seat
+				returned += layer.amount
+				giveBack(seat, layer.amount)
+			}
+			layers = layers[:count-1]
+		}
+	}
+
+	// The first level goes to the last pot, if it
+	// is still open, and the other levels become
+	// new side pots. Empty levels (e.g. when the
+	// only bet went uncalled) are dropped, and the
+	// ones nobody can win go to the last pot.
+	result := append(make([]*Pot, 0, len(current)+len(layers)), current...)
+	if count := len(result); count != 0 && len(layers) != 0 && !isClosed(result[count-1], contributions) {
+		result[count-1].merge(layers[0])
+		layers = layers[1:]
+	}
+	for _, layer := range layers {
+		if layer.amount == 0 {
+			continue
+		} else if count := len(result); count != 0 && len(layer.seats) == 0 {
+			result[count-1].merge(layer)
+		} else {
+			result = append(result, layer)
+		}
+	}
+
+	// Finally, clear the folded seats from the pots
+	// and reset the seats' pots.
+	for _, seat := range tableSeats {
+		if seat.Status() == seats.Folded {
+			for _, pot := range result {
+				pot.SeatHasLeft(seat)
+			}
+		}
+		if _, ok := contributions[seat]; ok {
+			seat.SetPot(0)
+		}
+	}
+	return result, returnedSeat, returned
+}
+
+// Collects the bets of all the seats as dead money: all
+// the seats not folded become eligible for the chips,
+// regardless of how many chips they put. This is meant
+// for chips posted by a single seat on behalf of all the
+// other seats (e.g. a big blind ante, or a button ante)
+// at the beginning of a hand. The chips go to the last
+// pot, if it is still open, or to a new pot.
+//
+// The given seats must be in table order, and their pots
+// are reset.
+func CollectDead(current []*Pot, tableSeats []seats.Seat) []*Pot {
+	dead := NewPot(0, nil)
+	contributions := map[seats.Seat]uint64{}
+	for _, seat := range tableSeats {
+		if status := seat.Status(); status == seats.Active || status == seats.AllIn {
+			dead.seats[seat] = true
+		}
+		if chips := seat.Pot(); chips != 0 {
+			dead.amount += chips
+			dead.contributions[seat] = chips
+			contributions[seat] = chips
+			seat.SetPot(0)
+		}
+	}
+	if dead.amount == 0 {
+		return current
+	}
+	result := append([]*Pot{}, current...)
+	if count := len(result); count != 0 && !isClosed(result[count-1], contributions) {
+		result[count-1].merge(dead)
+	} else {
+		result = append(result, dead)
+	}
+	return result
+}
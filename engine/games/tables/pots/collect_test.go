@@ -0,0 +1,297 @@
+package pots
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Moves chips from the stack to the pot, and sets
+// the given status to the seat.
+func bet(seat seats.Seat, chips uint64, status seats.Status) {
+	seat.SubStack(chips)
+	seat.AddPot(chips)
+	seat.SetStatus(status)
+}
+
+func testPot(t *testing.T, label string, pot *Pot, amount uint64, involvedSeats ...seats.Seat) {
+	if pot.Amount() != amount {
+		t.Errorf("%s: expected amount %d, got %d", label, amount, pot.Amount())
+	}
+	gotSeats := pot.Seats()
+	if len(gotSeats) != len(involvedSeats) {
+		t.Errorf("%s: expected %d involved seats, got %d", label, len(involvedSeats), len(gotSeats))
+		return
+	}
+	for _, seat := range involvedSeats {
+		if !pot.Involves(seat) {
+			t.Errorf("%s: expected seat %d to be involved", label, seat.SeatID())
+		}
+	}
+}
+
+func testPotsCount(t *testing.T, result []*Pot, count int) bool {
+	if len(result) != count {
+		t.Errorf("expected %d pots, got %d", count, len(result))
+		return false
+	}
+	return true
+}
+
+func testSeatPotsAreReset(t *testing.T, tableSeats []seats.Seat) {
+	for _, seat := range tableSeats {
+		if seat.Pot() != 0 {
+			t.Errorf("expected seat %d pot to be reset, got %d", seat.SeatID(), seat.Pot())
+		}
+	}
+}
+
+func testReturned(t *testing.T, returnedSeat seats.Seat, returned uint64, expectedSeat seats.Seat, expected uint64) {
+	if returnedSeat != expectedSeat || returned != expected {
+		t.Errorf("expected %d chips to be returned, got %d", expected, returned)
+	}
+}
+
+func TestCollectEqualBets(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 100, seats.Active)
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 300, s[0], s[1], s[2])
+	}
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectNothing(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	result, _, _ := Collect(nil, s)
+	testPotsCount(t, result, 0)
+}
+
+func TestCollectOneShortAllIn(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 150, s[0], s[1], s[2])
+		testPot(t, "side", result[1], 100, s[1], s[2])
+	}
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectMultiWayAllIns(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000, 1000, 1000)
+	bet(s[0], 60, seats.AllIn)
+	bet(s[1], 30, seats.AllIn)
+	bet(s[2], 100, seats.Active)
+	bet(s[3], 10, seats.Folded)
+	bet(s[4], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 3) {
+		testPot(t, "main", result[0], 130, s[0], s[1], s[2], s[4])
+		testPot(t, "side 1", result[1], 90, s[0], s[2], s[4])
+		testPot(t, "side 2", result[2], 80, s[2], s[4])
+		if result[0].Contribution(s[3]) != 10 {
+			t.Errorf("expected folded seat to contribute 10 to the main pot")
+		}
+		if result[1].Contribution(s[0]) != 30 {
+			t.Errorf("expected seat 1 to contribute 30 to the first side pot")
+		}
+	}
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectSameAllInLevels(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000, 1000)
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 50, seats.AllIn)
+	bet(s[2], 100, seats.Active)
+	bet(s[3], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 200, s[0], s[1], s[2], s[3])
+		testPot(t, "side", result[1], 100, s[2], s[3])
+	}
+}
+
+func TestCollectFoldedAboveAllIn(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000, 1000)
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 100, seats.Folded)
+	bet(s[2], 100, seats.Active)
+	bet(s[3], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 200, s[0], s[2], s[3])
+		testPot(t, "side", result[1], 150, s[2], s[3])
+	}
+}
+
+func TestCollectDeadMoneyLevel(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 80, seats.Folded)
+	bet(s[2], 50, seats.AllIn)
+	result, _, _ := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 180, s[0], s[2])
+	}
+}
+
+func TestCollectAcrossRounds(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 100, seats.Active)
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	// Second round: the first seat goes all-in, so
+	// the main pot receives its level.
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 200, seats.Active)
+	bet(s[2], 200, seats.Active)
+	result, _, _ = Collect(result, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 450, s[0], s[1], s[2])
+		testPot(t, "side", result[1], 300, s[1], s[2])
+	}
+	// Third round: the main pot is closed now, but
+	// the side pot is still open.
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ = Collect(result, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 450, s[0], s[1], s[2])
+		testPot(t, "side", result[1], 500, s[1], s[2])
+	}
+	// Fourth round: the second seat folds, so only
+	// the third one can win the chips of this round.
+	bet(s[1], 100, seats.Active)
+	s[1].SetStatus(seats.Folded)
+	bet(s[2], 100, seats.Active)
+	result, returnedSeat, returned := Collect(result, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 450, s[0], s[2])
+		testPot(t, "side", result[1], 500, s[2])
+	}
+	testReturned(t, returnedSeat, returned, s[2], 200)
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectAfterAllInOnExactLevel(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 100, seats.AllIn)
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	bet(s[1], 50, seats.Active)
+	bet(s[2], 50, seats.Active)
+	result, _, _ = Collect(result, s)
+	if testPotsCount(t, result, 2) {
+		testPot(t, "main", result[0], 300, s[0], s[1], s[2])
+		testPot(t, "side", result[1], 100, s[1], s[2])
+	}
+}
+
+func TestCollectUncalledBet(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 300, seats.Active)
+	bet(s[1], 100, seats.AllIn)
+	result, returnedSeat, returned := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 200, s[0], s[1])
+	}
+	testReturned(t, returnedSeat, returned, s[0], 200)
+	if s[0].Stack() != 900 {
+		t.Errorf("expected the stack to be 900, got %d", s[0].Stack())
+	}
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectUncalledBetAfterClosedPot(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 100, seats.AllIn)
+	bet(s[1], 100, seats.Active)
+	bet(s[2], 100, seats.Active)
+	result, _, _ := Collect(nil, s)
+	// Next round: the main pot is closed, and the only
+	// bet goes uncalled. No empty pot is added.
+	bet(s[1], 50, seats.Active)
+	s[2].SetStatus(seats.Folded)
+	result, returnedSeat, returned := Collect(result, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 300, s[0], s[1])
+	}
+	testReturned(t, returnedSeat, returned, s[1], 50)
+	testSeatPotsAreReset(t, s)
+}
+
+func TestCollectUncalledAllIn(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000)
+	bet(s[0], 1000, seats.AllIn)
+	bet(s[1], 200, seats.Active)
+	result, returnedSeat, returned := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 400, s[0], s[1])
+	}
+	testReturned(t, returnedSeat, returned, s[0], 800)
+	if s[0].Status() != seats.Active {
+		t.Errorf("expected the seat to be active again")
+	}
+}
+
+func TestCollectEverybodyFolded(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 5, seats.Folded)
+	bet(s[1], 10, seats.Folded)
+	bet(s[2], 30, seats.Active)
+	result, returnedSeat, returned := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 25, s[2])
+	}
+	testReturned(t, returnedSeat, returned, s[2], 20)
+}
+
+func TestCollectNoSingleSeatSidePot(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[0], 50, seats.AllIn)
+	bet(s[1], 100, seats.Folded)
+	bet(s[2], 300, seats.Active)
+	result, returnedSeat, returned := Collect(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "main", result[0], 150, s[0], s[2])
+	}
+	testReturned(t, returnedSeat, returned, s[2], 300)
+	if s[2].Stack() != 1000 {
+		t.Errorf("expected the stack to be 1000, got %d", s[2].Stack())
+	}
+}
+
+func TestCollectDead(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	bet(s[2], 30, seats.Active)
+	result := CollectDead(nil, s)
+	if testPotsCount(t, result, 1) {
+		testPot(t, "dead", result[0], 30, s[0], s[1], s[2])
+	}
+	testSeatPotsAreReset(t, s)
+	if result[0].Contribution(s[2]) != 30 {
+		t.Errorf("expected the contribution of the third seat to be 30")
+	}
+	if result = CollectDead(result, s); len(result) != 1 || result[0].Amount() != 30 {
+		t.Errorf("expected no changes when there are no bets")
+	}
+}
+
+func TestDivide(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000)
+	result := NewPot(101, s).Divide(3)
+	if testPotsCount(t, result, 3) {
+		testPot(t, "first run", result[0], 34, s[0], s[1])
+		testPot(t, "second run", result[1], 34, s[0], s[1])
+		testPot(t, "third run", result[2], 33, s[0], s[1])
+	}
+}
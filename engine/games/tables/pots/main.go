@@ -1,6 +1,9 @@
 package pots
 
-import "github.com/luismasuelli/poker-go/engine/games/tables/seats"
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"sort"
+)
 
 // This is a pot gathered in the table. This
 // means it is basically dead money, and also
@@ -11,9 +14,14 @@ import "github.com/luismasuelli/poker-go/engine/games/tables/seats"
 // will be notified when a seat left its active
 // status (i.e. folded [-> sit out [-> left]])
 // and will pop the seat from the pot.
+//
+// Pots built by collecting the seats' bets
+// also keep track of how many chips each seat
+// (even the folded ones) put into them.
 type Pot struct {
-	amount uint64
-	seats  map[seats.Seat]bool
+	amount        uint64
+	seats         map[seats.Seat]bool
+	contributions map[seats.Seat]uint64
 }
 
 // Creates a new pot, with the amount and the
@@ -23,7 +31,7 @@ func NewPot(amount uint64, involvedSeats []seats.Seat) *Pot {
 	for _, seat := range involvedSeats {
 		involvedSeatsSet[seat] = true
 	}
-	return &Pot{amount, involvedSeatsSet}
+	return &Pot{amount, involvedSeatsSet, map[seats.Seat]uint64{}}
 }
 
 // This method is invoked when a seat has left
@@ -41,11 +49,60 @@ func (pot *Pot) Amount() uint64 {
 	return pot.amount
 }
 
+// Takes up to the given chips from the pot (e.g.
+// for the rake), and returns how many chips were
+// actually taken.
+func (pot *Pot) Take(chips uint64) uint64 {
+	if chips > pot.amount {
+		chips = pot.amount
+	}
+	pot.amount -= chips
+	return chips
+}
+
+// Tells whether a seat is involved in this
+// pot (i.e. it may win it).
+func (pot *Pot) Involves(seat seats.Seat) bool {
+	_, ok := pot.seats[seat]
+	return ok
+}
+
+// Gets the seats involved in this pot, sorted
+// by their seat IDs.
+func (pot *Pot) Seats() []seats.Seat {
+	involvedSeats := make([]seats.Seat, 0, len(pot.seats))
+	for seat := range pot.seats {
+		involvedSeats = append(involvedSeats, seat)
+	}
+	sort.Slice(involvedSeats, func(i, j int) bool {
+		return involvedSeats[i].SeatID() < involvedSeats[j].SeatID()
+	})
+	return involvedSeats
+}
+
+// Gets how many chips a seat put in this pot.
+// Seats that already left the pot (e.g. they
+// folded) still keep their contributions.
+func (pot *Pot) Contribution(seat seats.Seat) uint64 {
+	return pot.contributions[seat]
+}
+
+// Gets a copy of all the contributions to this
+// pot, by seat.
+func (pot *Pot) Contributions() map[seats.Seat]uint64 {
+	contributions := map[seats.Seat]uint64{}
+	for seat, chips := range pot.contributions {
+		contributions[seat] = chips
+	}
+	return contributions
+}
+
 // Splits the pot in sub-pots of the given
 // quantities and the same participants. If
 // the quantities surpass the whole pot, a
 // final pot is added with the remaining
-// quantities.
+// quantities. Split pots do not keep track
+// of the seats' contributions.
 func (pot *Pot) Split(amounts ...uint64) []*Pot {
 	remainingAmount := pot.amount
 	splitPots := make([]*Pot, 0)
@@ -58,7 +115,7 @@ func (pot *Pot) Split(amounts ...uint64) []*Pot {
 	// exit the loop.
 	for _, amount := range amounts {
 		if amount < remainingAmount {
-			splitPots = append(splitPots, &Pot{amount, pot.seats})
+			splitPots = append(splitPots, &Pot{amount, pot.seats, map[seats.Seat]uint64{}})
 			remainingAmount -= amount
 		} else {
 			break
@@ -68,10 +125,30 @@ func (pot *Pot) Split(amounts ...uint64) []*Pot {
 	// amount. This will make us have such amount
 	// be added to the end of the list, as a new
 	// and final pot.
-	splitPots = append(splitPots, &Pot{remainingAmount, pot.seats})
+	splitPots = append(splitPots, &Pot{remainingAmount, pot.seats, map[seats.Seat]uint64{}})
 	return splitPots
 }
 
+// Divides the pot in the given number of equal
+// parts (e.g. one per board, when the board is
+// run more than once), with the same involved
+// seats. The odd chips go to the first parts.
+// Divided pots do not keep track of the seats'
+// contributions.
+func (pot *Pot) Divide(parts int) []*Pot {
+	dividedPots := make([]*Pot, parts)
+	amount := pot.amount / uint64(parts)
+	remainder := pot.amount % uint64(parts)
+	for index := range dividedPots {
+		part := amount
+		if uint64(index) < remainder {
+			part++
+		}
+		dividedPots[index] = &Pot{part, pot.seats, map[seats.Seat]uint64{}}
+	}
+	return dividedPots
+}
+
 // Divides equally the amount of the pot among
 // the winners that are involved with this pot.
 // An intersection of such players is considered,
@@ -0,0 +1,142 @@
+package rotation
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+// A variant of a mixed games table. The driver brings its
+// own evaluators and deck template, and is played with the
+// given betting structure.
+type Variant struct {
+	Name      string
+	Structure structures.Structure
+	// Whether the variant uses the button and the blinds.
+	// Otherwise (e.g. stud games, with the bring-in) the
+	// button stays while the variant is played.
+	Button bool
+	// Plays a whole hand of the variant, given the hand
+	// context and the positions.
+	Play func(hand *hands.Hand, positions *button.Hand)
+}
+
+// The rule to switch the variants.
+type Mode uint8
+
+const (
+	// The variants rotate in order after each orbit,
+	// i.e. as many hands as seats able to play when
+	// the variant starts.
+	Orbit Mode = iota
+	// The variants rotate in order after a fixed
+	// number of hands.
+	Hands
+	// The button picks the variant for each orbit.
+	DealersChoice
+)
+
+// The chooser asks the player in the button for the
+// variant to play, in dealer's choice. Implementations
+// must always return an index among the variants, even
+// when the player does not respond in time.
+type Chooser interface {
+	Choose(seat seats.Seat, variants []Variant) int
+}
+
+// A function can be used as a chooser.
+type ChooserFunc func(seat seats.Seat, variants []Variant) int
+
+// Invokes the function.
+func (chooser ChooserFunc) Choose(seat seats.Seat, variants []Variant) int {
+	return chooser(seat, variants)
+}
+
+// A rotation plays the hands of a mixed games table,
+// switching the variants (their drivers, evaluators,
+// deck templates and betting structures) by segments,
+// and announcing each one to the table.
+type Rotation struct {
+	mode     Mode
+	hands    int
+	variants []Variant
+	manager  *button.Manager
+	chooser  Chooser
+	current  int
+	played   int
+	length   int
+}
+
+// Creates a new rotation among the given variants. The
+// number of hands is only meaningful in the Hands mode,
+// and the chooser in the DealersChoice mode. The button
+// manager decides the positions of the variants using
+// the button.
+func NewRotation(mode Mode, hands int, variants []Variant, manager *button.Manager, chooser Chooser) *Rotation {
+	return &Rotation{mode: mode, hands: hands, variants: variants, manager: manager, chooser: chooser, current: -1}
+}
+
+// Gets the variant being played, or nil if no hand was
+// played yet.
+func (rotation *Rotation) Current() *Variant {
+	if rotation.current < 0 {
+		return nil
+	}
+	return &rotation.variants[rotation.current]
+}
+
+// Starts a new segment: picks the next variant (or asks
+// the button for it) and announces it to the table.
+func (rotation *Rotation) next(hand *hands.Hand, frozen *button.Hand) {
+	index := (rotation.current + 1) % len(rotation.variants)
+	if rotation.mode == DealersChoice && rotation.chooser != nil {
+		seat := frozen.Button
+		if seat == nil {
+			seat = frozen.DealtIn[0]
+		}
+		if choice := rotation.chooser.Choose(seat, rotation.variants); choice >= 0 && choice < len(rotation.variants) {
+			index = choice
+		}
+	}
+	rotation.current = index
+	rotation.played = 0
+	if rotation.mode == Hands {
+		rotation.length = rotation.hands
+	} else {
+		rotation.length = len(frozen.DealtIn)
+	}
+	hand.Broadcaster.NotifyTable(hand.GameID, hand.TableID, tables.NextVariantHasBeenAnnounced{
+		Name:  rotation.variants[rotation.current].Name,
+		Hands: rotation.length,
+	})
+}
+
+// Plays the next hand, switching the variant when the
+// segment is over. The hand context gets the seats and
+// the positions from the button manager (which moves the
+// button only for the variants using it), and the betting
+// structure of the variant. Returns an error if there are
+// not enough players to play.
+func (rotation *Rotation) Play(hand *hands.Hand) error {
+	frozen, err := rotation.manager.Frozen()
+	if err != nil {
+		return err
+	}
+	if rotation.current < 0 || rotation.played >= rotation.length {
+		rotation.next(hand, frozen)
+	}
+	variant := rotation.variants[rotation.current]
+	positions := frozen
+	if variant.Button {
+		if positions, err = rotation.manager.Next(); err != nil {
+			return err
+		}
+	}
+	hand.Seats = positions.DealtIn
+	hand.Structure = variant.Structure
+	variant.Play(hand, positions)
+	rotation.played++
+	return nil
+}
@@ -0,0 +1,100 @@
+package rotation
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/hands"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Keeps the announced variants.
+type announcements []tables.NextVariantHasBeenAnnounced
+
+func (recorder *announcements) Notify(message interface{}) {
+	content := message.(games.GameMessage).Content.(tables.TableMessage).Content
+	if announced, ok := content.(tables.NextVariantHasBeenAnnounced); ok {
+		*recorder = append(*recorder, announced)
+	}
+}
+
+// A played hand: the variant, and the button.
+type played struct {
+	name   string
+	button seats.Seat
+}
+
+func makeVariants(log *[]played) []Variant {
+	variant := func(name string, usesButton bool) Variant {
+		return Variant{Name: name, Button: usesButton, Play: func(hand *hands.Hand, positions *button.Hand) {
+			*log = append(*log, played{name, positions.Button})
+		}}
+	}
+	return []Variant{variant("Hold'em", true), variant("Stud", false)}
+}
+
+func playHands(t *testing.T, mode Mode, count int, chooser Chooser) ([]seats.Seat, []played, announcements) {
+	s := make([]seats.Seat, 3)
+	for index := range s {
+		seat := seats.NewBaseSeat(uint8(index + 1))
+		seat.Sit(&seatstest.Player{}, 1000)
+		s[index] = seat
+	}
+	var log []played
+	var recorder announcements
+	rotation := NewRotation(mode, 2, makeVariants(&log), button.NewManager(button.DeadButton, s), chooser)
+	for index := 0; index < count; index++ {
+		hand := &hands.Hand{GameID: 1, TableID: 1, Broadcaster: environment.NewBroadcaster(s, &recorder)}
+		if err := rotation.Play(hand); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	return s, log, recorder
+}
+
+func testNames(t *testing.T, log []played, names ...string) {
+	if len(log) != len(names) {
+		t.Fatalf("expected %d hands, got %d", len(names), len(log))
+	}
+	for index, name := range names {
+		if log[index].name != name {
+			t.Errorf("expected hand %d to be of %s, not %s", index+1, name, log[index].name)
+		}
+	}
+}
+
+func TestOrbit(t *testing.T) {
+	s, log, recorder := playHands(t, Orbit, 7, nil)
+	testNames(t, log, "Hold'em", "Hold'em", "Hold'em", "Stud", "Stud", "Stud", "Hold'em")
+	if len(recorder) != 3 || recorder[0].Name != "Hold'em" || recorder[0].Hands != 3 || recorder[1].Name != "Stud" {
+		t.Errorf("unexpected announcements: %v", recorder)
+	}
+	// The button moves in Hold'em, stays in Stud, and
+	// moves again from there.
+	if log[0].button != s[0] || log[2].button != s[2] || log[3].button != s[2] || log[6].button != s[0] {
+		t.Errorf("unexpected buttons: %v", log)
+	}
+}
+
+func TestHands(t *testing.T) {
+	_, log, recorder := playHands(t, Hands, 5, nil)
+	testNames(t, log, "Hold'em", "Hold'em", "Stud", "Stud", "Hold'em")
+	if len(recorder) != 3 || recorder[1].Hands != 2 {
+		t.Errorf("unexpected announcements: %v", recorder)
+	}
+}
+
+func TestDealersChoice(t *testing.T) {
+	var choosers []seats.Seat
+	s, log, _ := playHands(t, DealersChoice, 4, ChooserFunc(func(seat seats.Seat, variants []Variant) int {
+		choosers = append(choosers, seat)
+		return 1
+	}))
+	testNames(t, log, "Stud", "Stud", "Stud", "Stud")
+	if len(choosers) != 2 || choosers[0] != s[0] {
+		t.Errorf("expected the first seat to choose, and then once per orbit: %v", choosers)
+	}
+}
@@ -35,6 +35,22 @@ const (
 	Nothing Flags = 0
 	// The seat is marked as "sit out".
 	SitOut Flags = 1
+	// The seat missed its small blind while
+	// sitting out (or being away).
+	MissedSmall Flags = 2
+	// The seat missed its big blind while
+	// sitting out (or being away).
+	MissedBig Flags = 4
+	// The seat wants to post its missed
+	// blinds to play right now, instead of
+	// waiting for the big blind.
+	WantsToPost Flags = 8
+	// The seat mucks its losing hands at
+	// the showdown, without being asked.
+	AutoMuck Flags = 16
+	// The seat wants to straddle, when its
+	// position allows it.
+	Straddle Flags = 32
 )
 
 // Interfaces for a seat. There are
@@ -48,6 +64,8 @@ type Seat interface {
 	Player() players.Player
 	// The stack.
 	Stack() uint64
+	// The current pot (bets not yet collected).
+	Pot() uint64
 	// The status.
 	Status() Status
 	// The flags.
@@ -142,6 +160,11 @@ type BaseSeat struct {
 	cards  []*SeatCard
 }
 
+// Creates a new, free, seat with the given ID.
+func NewBaseSeat(seatID uint8) *BaseSeat {
+	return &BaseSeat{seatID: seatID, cards: make([]*SeatCard, 0)}
+}
+
 // Gets the seat ID.
 func (seat *BaseSeat) SeatID() uint8 {
 	return seat.seatID
@@ -171,8 +194,10 @@ func (seat *BaseSeat) Status() Status {
 	return seat.status
 }
 
-// Returns the flags of this seat. So far,
-// this only involves the "sit out" button.
+// Returns the flags of this seat: the "sit
+// out" button, the missed blinds (and the
+// wish to post them), the auto-muck
+// preference, and the wish to straddle.
 func (seat *BaseSeat) Flags() Flags {
 	return seat.flags
 }
@@ -285,7 +310,7 @@ func (seat *BaseSeat) SubStack(chips uint64) error {
 func (seat *BaseSeat) AddStack(chips uint64) error {
 	if seat.player == nil {
 		return ErrCannotChangeStackOnEmptySeat
-	} else if seat.stack > (^(uint64(0) - chips)) {
+	} else if seat.stack > ^uint64(0)-chips {
 		return ErrCannotContainChipsAddition
 	} else {
 		seat.stack += chips
@@ -336,7 +361,7 @@ func (seat *BaseSeat) SubPot(chips uint64) error {
 func (seat *BaseSeat) AddPot(chips uint64) error {
 	if seat.player == nil {
 		return ErrCannotChangePotOnEmptySeat
-	} else if seat.pot > (^(uint64(0) - chips)) {
+	} else if seat.pot > ^uint64(0)-chips {
 		return ErrCannotContainPotChipsAddition
 	} else {
 		seat.pot += chips
@@ -0,0 +1,40 @@
+package seats
+
+import (
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"testing"
+)
+
+// The seats package cannot use seatstest (it imports
+// this package), so it has its own player.
+type dummyPlayer struct{}
+
+func (player *dummyPlayer) Identification() interface{}                        { return player }
+func (player *dummyPlayer) Display() interface{}                               { return player }
+func (player *dummyPlayer) Notify(message interface{})                         {}
+func (player *dummyPlayer) FindHaving(asset assets.Asset) []players.Accounting { return nil }
+func (player *dummyPlayer) Get(asset assets.Asset) (uint64, error)             { return 0, nil }
+func (player *dummyPlayer) Add(asset assets.Asset, amount uint64) error        { return nil }
+func (player *dummyPlayer) Take(asset assets.Asset, amount uint64) error       { return nil }
+
+func TestAddOverflow(t *testing.T) {
+	max := ^uint64(0)
+	seat := NewBaseSeat(1)
+	seat.Sit(&dummyPlayer{}, 1000)
+	if err := seat.AddStack(500); err != nil || seat.Stack() != 1500 {
+		t.Errorf("expected the stack to be 1500, got %d (error: %v)", seat.Stack(), err)
+	}
+	if err := seat.AddStack(max - 1500); err != nil || seat.Stack() != max {
+		t.Errorf("expected the stack to be full, got %d (error: %v)", seat.Stack(), err)
+	}
+	if err := seat.AddStack(1); err != ErrCannotContainChipsAddition || seat.Stack() != max {
+		t.Errorf("expected ErrCannotContainChipsAddition, got %v", err)
+	}
+	if err := seat.AddPot(500); err != nil || seat.Pot() != 500 {
+		t.Errorf("expected the pot to be 500, got %d (error: %v)", seat.Pot(), err)
+	}
+	if err := seat.AddPot(max - 499); err != ErrCannotContainPotChipsAddition || seat.Pot() != 500 {
+		t.Errorf("expected ErrCannotContainPotChipsAddition, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package seatstest
+
+import (
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/players"
+)
+
+// A player to sit in the seats of the tests. It is
+// identified and displayed by itself, ignores the
+// messages, and keeps the chips credited to it (it
+// can always pay).
+type Player struct {
+	Credited uint64
+}
+
+func (player *Player) Identification() interface{}                        { return player }
+func (player *Player) Display() interface{}                               { return player }
+func (player *Player) Notify(message interface{})                         {}
+func (player *Player) FindHaving(asset assets.Asset) []players.Accounting { return nil }
+func (player *Player) Get(asset assets.Asset) (uint64, error)             { return player.Credited, nil }
+func (player *Player) Take(asset assets.Asset, amount uint64) error       { return nil }
+func (player *Player) Add(asset assets.Asset, amount uint64) error {
+	player.Credited += amount
+	return nil
+}
+
+// A notifiable ignoring the messages.
+type Notifiable struct{}
+
+func (notifiable *Notifiable) Notify(message interface{}) {}
+
+// Creates free seats, numbered from 1.
+func MakeSeats(count int) []seats.Seat {
+	result := make([]seats.Seat, count)
+	for index := range result {
+		result[index] = seats.NewBaseSeat(uint8(index + 1))
+	}
+	return result
+}
+
+// Creates seats with players sitting (and waiting)
+// with the given stacks.
+func MakeSitting(stacks ...uint64) []seats.Seat {
+	result := MakeSeats(len(stacks))
+	for index, seat := range result {
+		seat.Sit(&Player{}, stacks[index])
+	}
+	return result
+}
+
+// Creates seats with active players, with the given
+// stacks.
+func MakeActive(stacks ...uint64) []seats.Seat {
+	result := MakeSitting(stacks...)
+	for _, seat := range result {
+		seat.SetStatus(seats.Active)
+	}
+	return result
+}
+
+// Repeats the same stack for the given number of seats.
+func Stacks(count int, stack uint64) []uint64 {
+	result := make([]uint64, count)
+	for index := range result {
+		result[index] = stack
+	}
+	return result
+}
@@ -0,0 +1,408 @@
+package betting
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+var ErrRoundIsOver = errors.New("the betting round is over")
+var ErrNotSeatTurn = errors.New("it is not the turn of this seat to act")
+var ErrUnknownAction = errors.New("unknown betting action")
+var ErrCannotCheck = errors.New("cannot check when there is a bet to call")
+var ErrNothingToCall = errors.New("there is no bet to call")
+var ErrCannotBet = errors.New("cannot bet when there is already a bet: raise instead")
+var ErrNothingToRaise = errors.New("cannot raise when there is no bet: bet instead")
+var ErrActionNotReopened = errors.New("cannot raise since no full raise reopened the action")
+var ErrAmountTooSmall = errors.New("the amount is below the minimum allowed")
+var ErrAmountTooLarge = errors.New("the amount is above the maximum allowed")
+var ErrRaisesCapped = errors.New("no more raises are allowed in this round")
+
+// An illegal action attempted by a seat, in response
+// to a particular request of its player. The reason
+// is one of the Err* errors in this package.
+type ActionError struct {
+	RequestID engine.RequestID
+	SeatID    uint8
+	Action    actions.Action
+	Reason    error
+}
+
+// The error message is the one of the reason.
+func (err *ActionError) Error() string {
+	return err.Reason.Error()
+}
+
+// Unwraps the reason, so errors.Is can be used.
+func (err *ActionError) Unwrap() error {
+	return err.Reason
+}
+
+// The options of a seat when it is its turn to act.
+// Amounts for bets and raises are "to" amounts (i.e.
+// the total the seat will have put in the round).
+type Options struct {
+	// Chips needed to call (they may be less than
+	// the actual difference, if the seat cannot
+	// afford it).
+	ToCall uint64
+	// Whether the seat can check.
+	CanCheck bool
+	// Whether the seat can bet (there is no bet
+	// in the round, so far).
+	CanBet bool
+	// Whether the seat can raise (there is a bet,
+	// and the action is open for the seat).
+	CanRaise bool
+	// The minimum and maximum amounts for a bet
+	// or a raise, if allowed. An all-in below the
+	// minimum is allowed, anyway.
+	MinAmount uint64
+	MaxAmount uint64
+}
+
+// A betting round (e.g. pre-flop, or the flop, in Hold'Em)
+// is a state machine keeping track of the current bet, the
+// size of the last full raise, and which seats still have
+// to act. Seats put their chips in their pots (so they are
+// collected at the end of the round), and forced bets are
+// expected to be already posted when the round starts.
+//
+// The legal amounts of bets and raises are given by the
+// betting structure (e.g. no-limit). Seats may put all
+// their stack at any time, if the structure allows it,
+// but an all-in raise below the minimum raise does not
+// reopen the action for the seats that already acted,
+// unless incomplete raises add up to a full raise.
+type Round struct {
+	gameID        interface{}
+	tableID       uint32
+	broadcaster   *environment.Broadcaster
+	street        uint8
+	order         []seats.Seat
+	collected     []*pots.Pot
+	structure     structures.Structure
+	currentBet    uint64
+	lastRaise     uint64
+	fullRaises    int
+	faced         map[seats.Seat]int
+	matched       map[seats.Seat]uint64
+	pending       map[seats.Seat]bool
+	current       int
+	lastAggressor seats.Seat
+}
+
+// Creates a new betting round. The seats must be given in
+// the order they act in this round (the first seat to act
+// comes first), and only active seats will be asked to act.
+// The current bet is the greatest pot among the seats (e.g.
+// the posted big blind), and the bet size of the street in
+// the betting structure is the size of the first raise.
+// The collected pots are the ones of the former streets.
+func NewRound(gameID interface{}, tableID uint32, street uint8, order []seats.Seat, collected []*pots.Pot,
+	structure structures.Structure, broadcaster *environment.Broadcaster) *Round {
+	round := &Round{
+		gameID:      gameID,
+		tableID:     tableID,
+		broadcaster: broadcaster,
+		street:      street,
+		order:       order,
+		collected:   collected,
+		structure:   structure,
+		lastRaise:   structure.BetSize(street),
+		faced:       map[seats.Seat]int{},
+		matched:     map[seats.Seat]uint64{},
+		pending:     map[seats.Seat]bool{},
+		current:     -1,
+	}
+	for _, seat := range order {
+		if pot := seat.Pot(); pot > round.currentBet {
+			round.currentBet = pot
+		}
+		if seat.Status() == seats.Active {
+			round.pending[seat] = true
+		}
+	}
+	// Blinds greater than the bet size (e.g. straddles) act
+	// as full raises.
+	if round.currentBet > round.lastRaise {
+		round.lastRaise = round.currentBet
+	}
+	round.advance()
+	return round
+}
+
+// Tells whether the betting round is over.
+func (round *Round) Done() bool {
+	return len(round.pending) == 0
+}
+
+// Marks a seat as having opened the betting with a forced
+// bet (e.g. the bring-in, in stud games). Unlike the blinds,
+// the seat does not get the option to act again, unless a
+// seat bets or raises after it.
+func (round *Round) Opened(seat seats.Seat) {
+	round.acted(seat)
+	delete(round.pending, seat)
+	if round.current >= 0 && round.order[round.current] == seat {
+		round.advance()
+	}
+}
+
+// Gets the seat that has to act now, or nil if the round
+// is over.
+func (round *Round) ToAct() seats.Seat {
+	if round.Done() {
+		return nil
+	}
+	return round.order[round.current]
+}
+
+// Gets the current bet of the round.
+func (round *Round) CurrentBet() uint64 {
+	return round.currentBet
+}
+
+// Gets the size of the last full bet or raise.
+func (round *Round) LastRaise() uint64 {
+	return round.lastRaise
+}
+
+// Gets the last seat that made a bet or a raise in this
+// round, or nil if no seat did.
+func (round *Round) LastAggressor() seats.Seat {
+	return round.lastAggressor
+}
+
+// Counts the seats that did not fold.
+func (round *Round) remaining() int {
+	count := 0
+	for _, seat := range round.order {
+		if status := seat.Status(); status == seats.Active || status == seats.AllIn {
+			count++
+		}
+	}
+	return count
+}
+
+// Records the full raises a seat faced, and the bet it
+// matched, when it acted.
+func (round *Round) acted(seat seats.Seat) {
+	round.faced[seat] = round.fullRaises
+	round.matched[seat] = round.currentBet
+}
+
+// Tells whether a seat may raise: it did not act yet in
+// this round, a full raise happened after it acted, or
+// the incomplete raises since the bet it matched add up
+// to a full raise.
+func (round *Round) reopened(seat seats.Seat) bool {
+	faced, acted := round.faced[seat]
+	return !acted || round.fullRaises > faced || round.currentBet-round.matched[seat] >= round.lastRaise
+}
+
+// Gets the chips a seat needs to call the current bet.
+func (round *Round) toCall(seat seats.Seat) uint64 {
+	if pot := seat.Pot(); pot < round.currentBet {
+		return round.currentBet - pot
+	}
+	return 0
+}
+
+// Moves to the next seat that has to act, if any. Seats
+// not facing a bet, when no other seat could respond to
+// a bet from them, do not need to act.
+func (round *Round) advance() {
+	if round.remaining() < 2 {
+		round.pending = map[seats.Seat]bool{}
+		return
+	}
+	active := 0
+	for _, seat := range round.order {
+		if seat.Status() == seats.Active {
+			active++
+		}
+	}
+	count := len(round.order)
+	for step := 1; step <= count; step++ {
+		index := (round.current + step) % count
+		seat := round.order[index]
+		if !round.pending[seat] {
+			continue
+		}
+		if active < 2 && round.toCall(seat) == 0 {
+			delete(round.pending, seat)
+			continue
+		}
+		round.current = index
+		return
+	}
+	round.pending = map[seats.Seat]bool{}
+}
+
+// Describes the situation of a seat, as if the given
+// amount were the current bet.
+func (round *Round) situation(seat seats.Seat, currentBet uint64) structures.Situation {
+	return structures.Situation{
+		Street:     round.street,
+		Seats:      round.order,
+		Collected:  round.collected,
+		Seat:       seat,
+		CurrentBet: currentBet,
+		LastRaise:  round.lastRaise,
+	}
+}
+
+// Gets the minimum and maximum amounts for a bet or a
+// raise of a seat.
+func (round *Round) limits(seat seats.Seat) (uint64, uint64) {
+	return round.structure.Limits(round.situation(seat, round.currentBet))
+}
+
+// Tells whether no more raises are allowed.
+func (round *Round) capped(seat seats.Seat) bool {
+	return round.structure.Capped(round.situation(seat, round.currentBet))
+}
+
+// Gets the options of a seat, considering the current
+// state of the round.
+func (round *Round) Options(seat seats.Seat) Options {
+	toCall := round.toCall(seat)
+	total := seat.Pot() + seat.Stack()
+	capped := round.capped(seat)
+	options := Options{
+		ToCall:   toCall,
+		CanCheck: toCall == 0,
+		CanBet:   round.currentBet == 0 && seat.Stack() != 0 && !capped,
+		CanRaise: round.currentBet != 0 && total > round.currentBet && round.reopened(seat) && !capped,
+	}
+	if seat.Stack() < toCall {
+		options.ToCall = seat.Stack()
+	}
+	if options.CanBet || options.CanRaise {
+		options.MinAmount, options.MaxAmount = round.limits(seat)
+		if options.MinAmount > options.MaxAmount {
+			options.MinAmount = options.MaxAmount
+		}
+	}
+	return options
+}
+
+// Moves chips from the stack of a seat to its pot. The
+// seat becomes all-in if it has no more chips.
+func (round *Round) put(seat seats.Seat, chips uint64) {
+	seat.SubStack(chips)
+	seat.AddPot(chips)
+	if seat.Stack() == 0 {
+		seat.SetStatus(seats.AllIn)
+	}
+}
+
+// Makes a seat bet or raise to a given amount, checking
+// the limits (all-ins below the minimum are allowed) and
+// updating the current bet and the last full raise.
+func (round *Round) raise(seat seats.Seat, amount uint64) error {
+	if !round.reopened(seat) {
+		return ErrActionNotReopened
+	} else if round.capped(seat) {
+		return ErrRaisesCapped
+	}
+	min, max := round.limits(seat)
+	allIn := amount == seat.Pot()+seat.Stack()
+	if amount > max {
+		return ErrAmountTooLarge
+	} else if amount <= round.currentBet || (amount < min && !allIn) {
+		return ErrAmountTooSmall
+	}
+
+	round.put(seat, amount-seat.Pot())
+	if amount >= min {
+		round.lastRaise = amount - round.currentBet
+		round.fullRaises++
+	}
+	round.currentBet = amount
+	round.lastAggressor = seat
+	for _, other := range round.order {
+		if other != seat && other.Status() == seats.Active {
+			round.pending[other] = true
+		}
+	}
+	return nil
+}
+
+// Processes an action of a seat, in response to a request
+// of its player. The amount is only meaningful for bets and
+// raises, and is the total amount the seat will have put in
+// this round. Illegal actions are rejected with an error of
+// type *ActionError, and do not change the round.
+func (round *Round) Act(requestID engine.RequestID, seat seats.Seat, action actions.Action, amount uint64) error {
+	var reason error
+	pot, stack := seat.Pot(), seat.Stack()
+	toCall := round.toCall(seat)
+	if round.Done() {
+		reason = ErrRoundIsOver
+	} else if seat != round.ToAct() {
+		reason = ErrNotSeatTurn
+	} else {
+		switch action {
+		case actions.Fold:
+			seat.SetStatus(seats.Folded)
+		case actions.Check:
+			if toCall != 0 {
+				reason = ErrCannotCheck
+			}
+		case actions.Call:
+			if toCall == 0 {
+				reason = ErrNothingToCall
+			} else if toCall > stack {
+				round.put(seat, stack)
+			} else {
+				round.put(seat, toCall)
+			}
+		case actions.Bet:
+			if round.currentBet != 0 {
+				reason = ErrCannotBet
+			} else {
+				reason = round.raise(seat, amount)
+			}
+		case actions.Raise:
+			if round.currentBet == 0 {
+				reason = ErrNothingToRaise
+			} else {
+				reason = round.raise(seat, amount)
+			}
+		case actions.AllIn:
+			if pot+stack > round.currentBet {
+				reason = round.raise(seat, pot+stack)
+			} else {
+				round.put(seat, stack)
+			}
+		default:
+			reason = ErrUnknownAction
+		}
+	}
+	if reason != nil {
+		return &ActionError{requestID, seat.SeatID(), action, reason}
+	}
+
+	round.acted(seat)
+	delete(round.pending, seat)
+	var display interface{}
+	if player := seat.Player(); player != nil {
+		display = player.Display()
+	}
+	round.broadcaster.NotifySeat(round.gameID, round.tableID, seat.SeatID(), messages.SeatHasActed{
+		PlayerDisplay: display,
+		Action:        action,
+		Chips:         seat.Pot() - pot,
+		FinalPot:      seat.Pot(),
+		FinalStack:    seat.Stack(),
+	})
+	round.advance()
+	return nil
+}
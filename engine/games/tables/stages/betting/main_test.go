@@ -0,0 +1,286 @@
+package betting
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/rules/structures"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Posts a forced bet.
+func post(seat seats.Seat, chips uint64) {
+	seat.SubStack(chips)
+	seat.AddPot(chips)
+}
+
+func newRound(order []seats.Seat, bigBlind uint64) *Round {
+	return newStructuredRound(order, structures.NoLimit{BigBlind: bigBlind}, 0)
+}
+
+func newStructuredRound(order []seats.Seat, structure structures.Structure, street uint8) *Round {
+	return NewRound(1, 1, street, order, nil, structure, environment.NewBroadcaster(nil, &seatstest.Notifiable{}))
+}
+
+func act(t *testing.T, round *Round, seat seats.Seat, action actions.Action, amount uint64) {
+	if err := round.Act(0, seat, action, amount); err != nil {
+		t.Errorf("unexpected error for seat %d: %s", seat.SeatID(), err)
+	}
+}
+
+func testRejected(t *testing.T, round *Round, requestID engine.RequestID, seat seats.Seat, action actions.Action,
+	amount uint64, reason error) {
+	err := round.Act(requestID, seat, action, amount)
+	var actionError *ActionError
+	if !errors.As(err, &actionError) {
+		t.Errorf("expected an *ActionError, got %v", err)
+	} else if actionError.RequestID != requestID || actionError.SeatID != seat.SeatID() || actionError.Action != action {
+		t.Errorf("unexpected error data: %+v", actionError)
+	} else if !errors.Is(err, reason) {
+		t.Errorf("expected reason %q, got %q", reason, actionError.Reason)
+	}
+}
+
+func testToAct(t *testing.T, round *Round, seat seats.Seat) {
+	if toAct := round.ToAct(); toAct != seat {
+		if toAct == nil {
+			t.Errorf("expected seat %d to act, but the round is over", seat.SeatID())
+		} else {
+			t.Errorf("expected seat %d to act, not seat %d", seat.SeatID(), toAct.SeatID())
+		}
+	}
+}
+
+func TestPreFlopBigBlindOption(t *testing.T) {
+	// Seats: button (1), small blind (2), big blind (3).
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	post(s[1], 5)
+	post(s[2], 10)
+	round := newRound([]seats.Seat{s[0], s[1], s[2]}, 10)
+	testToAct(t, round, s[0])
+	testRejected(t, round, 1, s[1], actions.Call, 0, ErrNotSeatTurn)
+	testRejected(t, round, 2, s[0], actions.Check, 0, ErrCannotCheck)
+	testRejected(t, round, 3, s[0], actions.Bet, 30, ErrCannotBet)
+	act(t, round, s[0], actions.Call, 0)
+	act(t, round, s[1], actions.Call, 0)
+	testToAct(t, round, s[2])
+	if options := round.Options(s[2]); !options.CanCheck || !options.CanRaise || options.MinAmount != 20 {
+		t.Errorf("expected the big blind to have the option to check or raise to 20: %+v", options)
+	}
+	act(t, round, s[2], actions.Check, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over")
+	}
+	testRejected(t, round, 4, s[0], actions.Check, 0, ErrRoundIsOver)
+}
+
+func TestMinRaise(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	round := newRound(s, 10)
+	testRejected(t, round, 1, s[0], actions.Raise, 20, ErrNothingToRaise)
+	testRejected(t, round, 2, s[0], actions.Call, 0, ErrNothingToCall)
+	testRejected(t, round, 3, s[0], actions.Bet, 5, ErrAmountTooSmall)
+	testRejected(t, round, 4, s[0], actions.Bet, 1001, ErrAmountTooLarge)
+	act(t, round, s[0], actions.Bet, 30)
+	testRejected(t, round, 5, s[1], actions.Raise, 50, ErrAmountTooSmall)
+	act(t, round, s[1], actions.Raise, 100)
+	if round.LastRaise() != 70 || round.CurrentBet() != 100 || round.LastAggressor() != s[1] {
+		t.Errorf("expected a raise of 70 to 100 by the second seat")
+	}
+	testRejected(t, round, 6, s[2], actions.Raise, 169, ErrAmountTooSmall)
+	act(t, round, s[2], actions.Raise, 170)
+	act(t, round, s[0], actions.Fold, 0)
+	act(t, round, s[1], actions.Call, 0)
+	if !round.Done() || s[1].Pot() != 170 || s[1].Stack() != 830 {
+		t.Errorf("expected the round to be over with the second seat calling 170")
+	}
+}
+
+func TestIncompleteAllInDoesNotReopen(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 150)
+	round := newRound(s, 10)
+	act(t, round, s[0], actions.Bet, 100)
+	act(t, round, s[1], actions.Call, 0)
+	// An all-in for 150 is not a full raise (it should
+	// be to 200, at least).
+	act(t, round, s[2], actions.AllIn, 0)
+	if round.CurrentBet() != 150 || round.LastRaise() != 100 || s[2].Status() != seats.AllIn {
+		t.Errorf("expected an incomplete all-in raise to 150")
+	}
+	if options := round.Options(s[0]); options.CanRaise || options.ToCall != 50 {
+		t.Errorf("expected the first seat only to call or fold: %+v", options)
+	}
+	testRejected(t, round, 1, s[0], actions.Raise, 300, ErrActionNotReopened)
+	act(t, round, s[0], actions.Call, 0)
+	act(t, round, s[1], actions.Call, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over")
+	}
+}
+
+func TestIncompleteAllInsAddingUpReopen(t *testing.T) {
+	s := seatstest.MakeActive(1000, 160, 220, 1000)
+	round := newRound(s, 10)
+	act(t, round, s[0], actions.Bet, 100)
+	act(t, round, s[1], actions.AllIn, 0)
+	act(t, round, s[2], actions.AllIn, 0)
+	act(t, round, s[3], actions.Call, 0)
+	// Two incomplete raises, to 160 and 220, add up to
+	// a full raise over the original bet of 100.
+	if options := round.Options(s[0]); !options.CanRaise || options.MinAmount != 320 || options.ToCall != 120 {
+		t.Errorf("expected the action to be reopened for the first seat: %+v", options)
+	}
+	act(t, round, s[0], actions.Call, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over")
+	}
+}
+
+func TestIncompleteAllInsReopenByMatchedBet(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 150, 1000, 210)
+	round := newRound(s, 10)
+	act(t, round, s[0], actions.Bet, 100)
+	act(t, round, s[1], actions.Call, 0)
+	act(t, round, s[2], actions.AllIn, 0)
+	act(t, round, s[3], actions.Call, 0)
+	act(t, round, s[4], actions.AllIn, 0)
+	// The all-ins to 150 and 210 add up to a full raise
+	// over the bet of 100, but not over the 150 that the
+	// fourth seat matched.
+	for _, test := range []struct {
+		seat     seats.Seat
+		toCall   uint64
+		canRaise bool
+	}{
+		{s[0], 110, true},
+		{s[1], 110, true},
+		{s[3], 60, false},
+	} {
+		if options := round.Options(test.seat); options.CanRaise != test.canRaise || options.ToCall != test.toCall {
+			t.Errorf("unexpected options for seat %d: %+v", test.seat.SeatID(), options)
+		}
+	}
+	act(t, round, s[0], actions.Call, 0)
+	act(t, round, s[1], actions.Call, 0)
+	testRejected(t, round, 1, s[3], actions.Raise, 400, ErrActionNotReopened)
+	act(t, round, s[3], actions.Call, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over")
+	}
+}
+
+func TestShortCallAndNoActionNeeded(t *testing.T) {
+	s := seatstest.MakeActive(1000, 50)
+	round := newRound(s, 10)
+	act(t, round, s[0], actions.Bet, 200)
+	if options := round.Options(s[1]); options.ToCall != 50 || options.CanRaise {
+		t.Errorf("expected the short stack only to call all-in: %+v", options)
+	}
+	act(t, round, s[1], actions.Call, 0)
+	if !round.Done() || s[1].Status() != seats.AllIn || s[1].Pot() != 50 {
+		t.Errorf("expected the short stack to be all-in for 50, and the round to be over")
+	}
+
+	s = seatstest.MakeActive(1000, 1000, 1000)
+	s[1].SetStatus(seats.AllIn)
+	s[2].SetStatus(seats.Folded)
+	round = newRound(s, 10)
+	if !round.Done() {
+		t.Errorf("expected no action to be needed with only one active seat")
+	}
+}
+
+func TestFixedLimitCap(t *testing.T) {
+	structure := structures.FixedLimit{SmallBet: 10, BigBet: 20, BigStreet: 2, Cap: 4}
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	round := newStructuredRound(s, structure, 2)
+	testRejected(t, round, 1, s[0], actions.Bet, 10, ErrAmountTooSmall)
+	testRejected(t, round, 2, s[0], actions.Bet, 40, ErrAmountTooLarge)
+	act(t, round, s[0], actions.Bet, 20)
+	act(t, round, s[1], actions.Raise, 40)
+	act(t, round, s[2], actions.Raise, 60)
+	act(t, round, s[0], actions.Raise, 80)
+	if options := round.Options(s[1]); options.CanRaise {
+		t.Errorf("expected the raises to be capped")
+	}
+	testRejected(t, round, 3, s[1], actions.Raise, 100, ErrRaisesCapped)
+	act(t, round, s[1], actions.Fold, 0)
+	// Heads-up, the cap is lifted.
+	act(t, round, s[2], actions.Raise, 100)
+	act(t, round, s[0], actions.Call, 0)
+	if !round.Done() || s[0].Pot() != 100 || s[2].Pot() != 100 {
+		t.Errorf("expected the round to be over at 100")
+	}
+}
+
+func TestPotLimitMaximum(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	post(s[1], 5)
+	post(s[2], 10)
+	round := newStructuredRound(s, structures.PotLimit{BigBlind: 10}, 0)
+	// Calling 10 makes the pot 25, so the maximum is 35.
+	if options := round.Options(s[0]); options.MinAmount != 20 || options.MaxAmount != 35 {
+		t.Errorf("expected raises from 20 to 35: %+v", options)
+	}
+	testRejected(t, round, 1, s[0], actions.Raise, 36, ErrAmountTooLarge)
+	act(t, round, s[0], actions.Raise, 35)
+	// Calling 30 makes the pot 80, so the maximum is 115.
+	if options := round.Options(s[1]); options.MaxAmount != 115 {
+		t.Errorf("expected raises up to 115: %+v", options)
+	}
+}
+
+func TestBringInHasNoOption(t *testing.T) {
+	structure := structures.FixedLimit{SmallBet: 10, BigBet: 20, BigStreet: 2}
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	post(s[1], 3)
+	round := newStructuredRound([]seats.Seat{s[2], s[0], s[1]}, structure, 0)
+	round.Opened(s[1])
+	if options := round.Options(s[2]); options.ToCall != 3 || options.MinAmount != 10 || options.MaxAmount != 10 {
+		t.Errorf("expected the bring-in to be completed to 10: %+v", options)
+	}
+	act(t, round, s[2], actions.Call, 0)
+	act(t, round, s[0], actions.Call, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over, with no option for the bring-in")
+	}
+
+	s = seatstest.MakeActive(1000, 1000, 1000)
+	post(s[1], 3)
+	round = newStructuredRound([]seats.Seat{s[2], s[0], s[1]}, structure, 0)
+	round.Opened(s[1])
+	act(t, round, s[2], actions.Raise, 10)
+	act(t, round, s[0], actions.Call, 0)
+	testToAct(t, round, s[1])
+	if options := round.Options(s[1]); options.ToCall != 7 || !options.CanRaise || options.MinAmount != 20 {
+		t.Errorf("expected the bring-in to call 7, or raise to 20: %+v", options)
+	}
+}
+
+func TestStraddleOption(t *testing.T) {
+	// Seats: under the gun (1), button (2), small blind
+	// (3), big blind (4). The first seat straddles, and
+	// acts last.
+	s := seatstest.MakeActive(1000, 1000, 1000, 1000)
+	post(s[2], 5)
+	post(s[3], 10)
+	post(s[0], 20)
+	round := newRound([]seats.Seat{s[1], s[2], s[3], s[0]}, 10)
+	if options := round.Options(s[1]); options.ToCall != 20 || options.MinAmount != 40 {
+		t.Errorf("expected the straddle to be called or raised to 40: %+v", options)
+	}
+	act(t, round, s[1], actions.Call, 0)
+	act(t, round, s[2], actions.Call, 0)
+	act(t, round, s[3], actions.Call, 0)
+	testToAct(t, round, s[0])
+	if options := round.Options(s[0]); !options.CanCheck || !options.CanRaise || options.MinAmount != 40 {
+		t.Errorf("expected the straddle to have the option to check or raise to 40: %+v", options)
+	}
+	act(t, round, s[0], actions.Check, 0)
+	if !round.Done() {
+		t.Errorf("expected the round to be over")
+	}
+}
@@ -0,0 +1,28 @@
+package collect
+
+import (
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+// Collects the bets of all the seats into the current
+// pots, at the end of a betting round, and returns the
+// new pots. The uncalled part of a bet (if any) goes
+// back to the stack of the seat that made it, and this
+// is notified as a seat message.
+//
+// This function is called once per betting round, and
+// the given seats must be in table order.
+func CollectPots(gameID interface{}, tableID uint32, tableSeats []seats.Seat, current []*pots.Pot,
+	broadcaster *environment.Broadcaster) []*pots.Pot {
+	result, returnedSeat, returned := pots.Collect(current, tableSeats)
+	if returned != 0 {
+		broadcaster.NotifySeat(gameID, tableID, returnedSeat.SeatID(), messages.SeatUncalledBetHasBeenReturned{
+			Chips:      returned,
+			FinalStack: returnedSeat.Stack(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package forced
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+)
+
+// The forced bets of the games with blinds. The ante
+// may be posted by each seat dealt in, or by the big
+// blind on behalf of everybody. Straddles may also be
+// allowed, of the given amount (0 means twice the big
+// blind).
+type Blinds struct {
+	Small          uint64
+	Big            uint64
+	Ante           uint64
+	BigBlindAnte   bool
+	Straddle       Straddle
+	StraddleAmount uint64
+}
+
+// Posts all the forced bets of a hand, given the positions
+// decided by the button manager: the antes (collected as
+// dead money), the missed blinds and the regular blinds, in
+// that order. Returns the pots.
+func (blinds Blinds) Post(gameID interface{}, tableID uint32, positions *button.Hand,
+	broadcaster *environment.Broadcaster) []*pots.Pot {
+	var result []*pots.Pot
+	if blinds.Ante != 0 {
+		if blinds.BigBlindAnte {
+			result = PostBigBlindAnte(gameID, tableID, positions.DealtIn, positions.Big, blinds.Ante, blinds.Big,
+				result, broadcaster)
+		} else {
+			result = PostAntes(gameID, tableID, positions.DealtIn, blinds.Ante, result, broadcaster)
+		}
+	}
+	result = PostMissedBlinds(gameID, tableID, positions.DealtIn, positions.DeadSmall, positions.LiveBig,
+		blinds.Small, blinds.Big, result, broadcaster)
+	PostBlinds(gameID, tableID, positions.Small, positions.Big, blinds.Small, blinds.Big, broadcaster)
+	return result
+}
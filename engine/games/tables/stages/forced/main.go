@@ -0,0 +1,116 @@
+package forced
+
+import (
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/stages/collect"
+)
+
+// Posts a forced bet for a seat: moves the chips from its
+// stack to its pot, and notifies it as a seat message. If
+// the seat cannot afford the whole amount, it posts all its
+// stack and becomes all-in. Returns the posted chips.
+func Post(gameID interface{}, tableID uint32, seat seats.Seat, forced actions.Forced, amount uint64,
+	broadcaster *environment.Broadcaster) uint64 {
+	if stack := seat.Stack(); amount > stack {
+		amount = stack
+	}
+	if amount == 0 {
+		return 0
+	}
+	seat.SubStack(amount)
+	seat.AddPot(amount)
+	if seat.Stack() == 0 {
+		seat.SetStatus(seats.AllIn)
+	}
+	var display interface{}
+	if player := seat.Player(); player != nil {
+		display = player.Display()
+	}
+	broadcaster.NotifySeat(gameID, tableID, seat.SeatID(), messages.SeatHasPosted{
+		PlayerDisplay: display,
+		Forced:        forced,
+		Chips:         amount,
+		FinalPot:      seat.Pot(),
+		FinalStack:    seat.Stack(),
+	})
+	return amount
+}
+
+// Posts the small and big blinds. The small blind seat may
+// be nil (e.g. when the small blind is dead). Blinds are live
+// bets, so they stay in the seats' pots for the first round.
+func PostBlinds(gameID interface{}, tableID uint32, small, big seats.Seat, smallBlind, bigBlind uint64,
+	broadcaster *environment.Broadcaster) {
+	if small != nil {
+		Post(gameID, tableID, small, actions.SmallBlind, smallBlind, broadcaster)
+	}
+	Post(gameID, tableID, big, actions.BigBlind, bigBlind, broadcaster)
+}
+
+// Posts an ante for each seat dealt in, and collects them
+// into the pots (antes are dead money, and must be collected
+// before the blinds are posted). Returns the new pots.
+func PostAntes(gameID interface{}, tableID uint32, dealtIn []seats.Seat, ante uint64, current []*pots.Pot,
+	broadcaster *environment.Broadcaster) []*pots.Pot {
+	for _, seat := range dealtIn {
+		Post(gameID, tableID, seat, actions.Ante, ante, broadcaster)
+	}
+	return collect.CollectPots(gameID, tableID, dealtIn, current, broadcaster)
+}
+
+// Posts the ante of the big blind, on behalf of all the
+// seats dealt in, and collects it into the pots. The blind
+// has priority over the ante: a short big blind posts the
+// ante only with the chips exceeding the big blind. Returns
+// the new pots.
+func PostBigBlindAnte(gameID interface{}, tableID uint32, dealtIn []seats.Seat, big seats.Seat,
+	ante, bigBlind uint64, current []*pots.Pot, broadcaster *environment.Broadcaster) []*pots.Pot {
+	if stack := big.Stack(); stack <= bigBlind {
+		ante = 0
+	} else if stack-bigBlind < ante {
+		ante = stack - bigBlind
+	}
+	Post(gameID, tableID, big, actions.BigBlindAnte, ante, broadcaster)
+	return pots.CollectDead(current, dealtIn)
+}
+
+// Posts the ante of the button, on behalf of all the seats
+// dealt in, and collects it into the pots. Returns the new
+// pots.
+func PostButtonAnte(gameID interface{}, tableID uint32, dealtIn []seats.Seat, button seats.Seat,
+	ante uint64, current []*pots.Pot, broadcaster *environment.Broadcaster) []*pots.Pot {
+	Post(gameID, tableID, button, actions.ButtonAnte, ante, broadcaster)
+	return pots.CollectDead(current, dealtIn)
+}
+
+// Posts the bring-in of a stud game, or completes it to
+// the small bet if the seat chooses so. The bring-in is a
+// live bet. Returns the posted chips.
+func PostBringIn(gameID interface{}, tableID uint32, seat seats.Seat, bringIn, completion uint64, complete bool,
+	broadcaster *environment.Broadcaster) uint64 {
+	if complete {
+		return Post(gameID, tableID, seat, actions.Completion, completion, broadcaster)
+	}
+	return Post(gameID, tableID, seat, actions.BringIn, bringIn, broadcaster)
+}
+
+// Posts the missed blinds of the seats coming back to play:
+// dead small blinds are collected into the pots as dead money,
+// while missed big blinds are live bets, so they stay in the
+// seats' pots for the first round. This must be done before
+// the regular blinds are posted. Returns the new pots.
+func PostMissedBlinds(gameID interface{}, tableID uint32, dealtIn []seats.Seat, deadSmall, liveBig []seats.Seat,
+	smallBlind, bigBlind uint64, current []*pots.Pot, broadcaster *environment.Broadcaster) []*pots.Pot {
+	for _, seat := range deadSmall {
+		Post(gameID, tableID, seat, actions.DeadSmallBlind, smallBlind, broadcaster)
+	}
+	result := pots.CollectDead(current, dealtIn)
+	for _, seat := range liveBig {
+		Post(gameID, tableID, seat, actions.BigBlind, bigBlind, broadcaster)
+	}
+	return result
+}
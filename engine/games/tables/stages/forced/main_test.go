@@ -0,0 +1,170 @@
+package forced
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Keeps the posts notified to the table.
+type postsRecorder struct {
+	posts []messages.SeatHasPosted
+}
+
+func (recorder *postsRecorder) Notify(message interface{}) {
+	seatMessage := message.(games.GameMessage).Content.(tables.TableMessage).Content.(messages.SeatMessage)
+	if posted, ok := seatMessage.Content.(messages.SeatHasPosted); ok {
+		recorder.posts = append(recorder.posts, posted)
+	}
+}
+
+func testSeat(t *testing.T, seat seats.Seat, pot, stack uint64, status seats.Status) {
+	if seat.Pot() != pot || seat.Stack() != stack || seat.Status() != status {
+		t.Errorf("seat %d: expected pot %d, stack %d and status %d, got %d, %d and %d", seat.SeatID(),
+			pot, stack, status, seat.Pot(), seat.Stack(), seat.Status())
+	}
+}
+
+func TestPostBlinds(t *testing.T) {
+	recorder := &postsRecorder{}
+	s := seatstest.MakeActive(1000, 1000, 7)
+	PostBlinds(1, 1, s[1], s[2], 5, 10, environment.NewBroadcaster(nil, recorder))
+	testSeat(t, s[1], 5, 995, seats.Active)
+	testSeat(t, s[2], 7, 0, seats.AllIn)
+	if len(recorder.posts) != 2 || recorder.posts[0].Forced != actions.SmallBlind ||
+		recorder.posts[1].Forced != actions.BigBlind || recorder.posts[1].Chips != 7 {
+		t.Errorf("unexpected posts: %+v", recorder.posts)
+	}
+}
+
+func TestPostAntes(t *testing.T) {
+	recorder := &postsRecorder{}
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	result := PostAntes(1, 1, s, 2, nil, environment.NewBroadcaster(nil, recorder))
+	if len(result) != 1 || result[0].Amount() != 6 {
+		t.Errorf("expected the antes to be collected into a pot of 6")
+	}
+	for _, seat := range s {
+		testSeat(t, seat, 0, 998, seats.Active)
+	}
+	if len(recorder.posts) != 3 {
+		t.Errorf("expected 3 posts, got %d", len(recorder.posts))
+	}
+}
+
+func TestPostBigBlindAnte(t *testing.T) {
+	recorder := &postsRecorder{}
+	s := seatstest.MakeActive(1000, 1000, 15)
+	result := PostBigBlindAnte(1, 1, s, s[2], 10, 10, nil, environment.NewBroadcaster(nil, recorder))
+	// The big blind is given priority, so only 5 chips
+	// are posted as ante.
+	if len(result) != 1 || result[0].Amount() != 5 || len(result[0].Seats()) != 3 {
+		t.Errorf("expected a pot of 5 for all the seats")
+	}
+	testSeat(t, s[2], 0, 10, seats.Active)
+	if len(recorder.posts) != 1 || recorder.posts[0].Forced != actions.BigBlindAnte {
+		t.Errorf("unexpected posts: %+v", recorder.posts)
+	}
+}
+
+func TestPostButtonAnte(t *testing.T) {
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	result := PostButtonAnte(1, 1, s, s[0], 15, nil, environment.NewBroadcaster(nil, &postsRecorder{}))
+	if len(result) != 1 || result[0].Amount() != 15 || len(result[0].Seats()) != 3 {
+		t.Errorf("expected a pot of 15 for all the seats")
+	}
+	testSeat(t, s[0], 0, 985, seats.Active)
+}
+
+func TestPostBringIn(t *testing.T) {
+	recorder := &postsRecorder{}
+	s := seatstest.MakeActive(1000, 1000)
+	broadcaster := environment.NewBroadcaster(nil, recorder)
+	if posted := PostBringIn(1, 1, s[0], 3, 10, false, broadcaster); posted != 3 {
+		t.Errorf("expected a bring-in of 3, got %d", posted)
+	}
+	if posted := PostBringIn(1, 1, s[1], 3, 10, true, broadcaster); posted != 10 {
+		t.Errorf("expected a completion to 10, got %d", posted)
+	}
+	if len(recorder.posts) != 2 || recorder.posts[0].Forced != actions.BringIn ||
+		recorder.posts[1].Forced != actions.Completion {
+		t.Errorf("unexpected posts: %+v", recorder.posts)
+	}
+}
+
+func TestPostMissedBlinds(t *testing.T) {
+	recorder := &postsRecorder{}
+	s := seatstest.MakeActive(1000, 1000, 1000)
+	result := PostMissedBlinds(1, 1, s, []seats.Seat{s[2]}, []seats.Seat{s[2]}, 5, 10, nil,
+		environment.NewBroadcaster(nil, recorder))
+	if len(result) != 1 || result[0].Amount() != 5 || len(result[0].Seats()) != 3 {
+		t.Errorf("expected a dead pot of 5 for all the seats")
+	}
+	testSeat(t, s[2], 10, 985, seats.Active)
+	if len(recorder.posts) != 2 || recorder.posts[0].Forced != actions.DeadSmallBlind ||
+		recorder.posts[1].Forced != actions.BigBlind {
+		t.Errorf("unexpected posts: %+v", recorder.posts)
+	}
+}
+
+// Gets the positions of a hand among five seats, with the
+// given ones opting in to straddle.
+func straddlePositions(t *testing.T, straddling ...int) *button.Hand {
+	s := seatstest.MakeActive(1000, 1000, 1000, 1000, 1000)
+	positions, err := button.NewManager(button.DeadButton, s).Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, index := range straddling {
+		if err := positions.DealtIn[index].SetFlag(seats.Straddle); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	return positions
+}
+
+func TestStraddler(t *testing.T) {
+	// The seats dealt in are: the small blind, the big
+	// blind, under the gun, the cutoff and the button.
+	positions := straddlePositions(t, 0, 2, 3)
+	if seat := (Blinds{Big: 10, Straddle: UTGStraddle}).Straddler(positions); seat != positions.DealtIn[2] {
+		t.Errorf("expected the seat under the gun to straddle")
+	}
+	if seat := (Blinds{Big: 10, Straddle: ButtonStraddle}).Straddler(positions); seat != nil {
+		t.Errorf("expected no button straddle, when the button does not opt in")
+	}
+	if seat := (Blinds{Big: 10, Straddle: MississippiStraddle}).Straddler(positions); seat != positions.DealtIn[3] {
+		t.Errorf("expected the cutoff to straddle")
+	}
+	if seat := (Blinds{Big: 10}).Straddler(positions); seat != nil {
+		t.Errorf("expected no straddles, when they are not allowed")
+	}
+	positions = straddlePositions(t, 4)
+	if seat := (Blinds{Big: 10, Straddle: ButtonStraddle, StraddleAmount: 2000}).Straddler(positions); seat != nil {
+		t.Errorf("expected no straddle, when the button cannot afford it")
+	}
+	recorder := &postsRecorder{}
+	blinds := Blinds{Big: 10, Straddle: ButtonStraddle}
+	if seat := blinds.PostStraddle(1, 1, positions, environment.NewBroadcaster(nil, recorder)); seat != positions.Button {
+		t.Errorf("expected the button to straddle")
+	}
+	testSeat(t, positions.Button, 20, 980, seats.Active)
+	if len(recorder.posts) != 1 || recorder.posts[0].Forced != actions.Straddle {
+		t.Errorf("unexpected posts: %+v", recorder.posts)
+	}
+}
+
+func TestValidateStraddle(t *testing.T) {
+	if err := (Blinds{Small: 5, Big: 10, Straddle: UTGStraddle}).Validate(); err != nil {
+		t.Errorf("expected the default straddle to be valid: %s", err)
+	}
+	if err := (Blinds{Small: 5, Big: 10, Straddle: UTGStraddle, StraddleAmount: 15}).Validate(); err != ErrStraddleTooSmall {
+		t.Errorf("expected a too small straddle, got %v", err)
+	}
+}
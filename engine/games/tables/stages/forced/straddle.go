@@ -0,0 +1,101 @@
+package forced
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/games/rules/actions"
+	"github.com/luismasuelli/poker-go/engine/games/tables/button"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+var ErrStraddleTooSmall = errors.New("the straddle must be at least twice the big blind")
+
+// The straddles allowed in a table. The straddling seat
+// must opt in (with the Straddle flag), and acts last
+// before the flop, while the seat at its left acts first.
+type Straddle uint8
+
+const (
+	// No straddles are allowed.
+	NoStraddle Straddle = iota
+	// The seat at the left of the big blind (i.e. under
+	// the gun) may straddle.
+	UTGStraddle
+	// The button may straddle.
+	ButtonStraddle
+	// Any seat but the blinds may straddle. When more
+	// than one seat opts in, the one closest to the
+	// button (i.e. acting later) straddles.
+	MississippiStraddle
+)
+
+// Validates the forced bets: straddles must be at least
+// twice the big blind.
+func (blinds Blinds) Validate() error {
+	if blinds.Straddle != NoStraddle && blinds.StraddleAmount != 0 && blinds.StraddleAmount < 2*blinds.Big {
+		return ErrStraddleTooSmall
+	}
+	return nil
+}
+
+// Gets the amount of the straddle.
+func (blinds Blinds) straddleAmount() uint64 {
+	if blinds.StraddleAmount == 0 {
+		return 2 * blinds.Big
+	}
+	return blinds.StraddleAmount
+}
+
+// Tells whether a seat may straddle: it must be active, not
+// in the blinds, opt in, and afford the whole straddle.
+func (blinds Blinds) canStraddle(positions *button.Hand, seat seats.Seat) bool {
+	return seat != nil && seat != positions.Small && seat != positions.Big &&
+		seat.Status() == seats.Active && seat.Flags()&seats.Straddle != 0 &&
+		seat.Stack() >= blinds.straddleAmount()
+}
+
+// Gets the seat straddling in a hand, given the positions
+// decided by the button manager, or nil if none. There are
+// no straddles heads-up.
+func (blinds Blinds) Straddler(positions *button.Hand) seats.Seat {
+	if blinds.Straddle == NoStraddle || positions.HeadsUp {
+		return nil
+	}
+	after := len(positions.DealtIn)
+	for index, seat := range positions.DealtIn {
+		if seat == positions.Big {
+			after = index + 1
+			break
+		}
+	}
+	switch blinds.Straddle {
+	case UTGStraddle:
+		if after < len(positions.DealtIn) && blinds.canStraddle(positions, positions.DealtIn[after]) {
+			return positions.DealtIn[after]
+		}
+	case ButtonStraddle:
+		if blinds.canStraddle(positions, positions.Button) {
+			return positions.Button
+		}
+	case MississippiStraddle:
+		for index := len(positions.DealtIn) - 1; index >= after; index-- {
+			if seat := positions.DealtIn[index]; blinds.canStraddle(positions, seat) {
+				return seat
+			}
+		}
+	}
+	return nil
+}
+
+// Posts the straddle, if any, after the blinds. It is a live
+// bet, and stays in the seat's pot for the first round. The
+// straddling seat is returned (nil if none), so the first
+// round starts at its left and ends with it.
+func (blinds Blinds) PostStraddle(gameID interface{}, tableID uint32, positions *button.Hand,
+	broadcaster *environment.Broadcaster) seats.Seat {
+	straddler := blinds.Straddler(positions)
+	if straddler != nil {
+		Post(gameID, tableID, straddler, actions.Straddle, blinds.straddleAmount(), broadcaster)
+	}
+	return straddler
+}
@@ -0,0 +1,10 @@
+package forced
+
+// The forced bets of the stud games: an ante for each
+// seat dealt in, and the bring-in, posted by the seat
+// with the worst up card (which may choose to complete
+// it to the small bet, instead).
+type Stud struct {
+	Ante    uint64
+	BringIn uint64
+}
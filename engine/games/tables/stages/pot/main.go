@@ -3,7 +3,7 @@ package pot
 import (
 	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
 	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
-	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/rules/oddchips"
 	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
 	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
 	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
@@ -23,8 +23,21 @@ import (
 //
 // The seats among pot players are active/all-in that
 // did not muck their hands.
-func AwardModePots(gameID interface{}, tableID uint32, handID uint64, mode showdowns.Mode,
-                   pots []*pots.Pot, podium showdowns.Podium, broadcaster *environment.Broadcaster) {
+//
+// When a pot cannot be evenly divided among the tied
+// winners, the odd chips are given according to the
+// policy (by default: in showdown order), which may
+// make use of the button (0 if there is no button).
+//
+// When the board is run more than once, this is done
+// once per board (the first board being 0), with the
+// part of the pots corresponding to that board.
+func AwardModePots(gameID interface{}, tableID uint32, handID uint64, board uint8, mode showdowns.Mode,
+                   pots []*pots.Pot, podium showdowns.Podium, policy oddchips.Policy, button uint8,
+                   broadcaster *environment.Broadcaster) {
+	if policy == nil {
+		policy = oddchips.ShowdownOrder{}
+	}
     // Iterate over all the side pots (and the
     // main point) for a given showdown.
     for potIndex, pot := range pots {
@@ -36,7 +49,7 @@ func AwardModePots(gameID interface{}, tableID uint32, handID uint64, mode showd
 			involvedWinners, amount, remainder := pot.Award(sameRankSeats)
 			if len(involvedWinners) != 0 {
 				// Divide this pot, and break.
-				for index, seat := range involvedWinners {
+				for index, seat := range policy.Prioritize(involvedWinners, button) {
 					// Get the seat ID, player display,
 					// money to award (including the
 					// remaining chip).
@@ -54,18 +67,13 @@ func AwardModePots(gameID interface{}, tableID uint32, handID uint64, mode showd
 					// Notify with a game message about this
 					// prize ($player received from $pot an
 					// amount of $chips).
-					broadcaster.Notify(games.GameMessage{
-						gameID,
-						tables.TableMessage{
-							tableID,
-							seats.SeatMessage{
-								seatID,
-								seats.PlayerWonChips{
-									display, handID, mode,
-									uint8(potIndex), prize,
-								},
-							},
-						},
+					broadcaster.NotifySeat(gameID, tableID, seatID, seats.PlayerWonChips{
+						Display:  display,
+						HandID:   handID,
+						Board:    board,
+						Mode:     mode,
+						PotIndex: uint8(potIndex),
+						Prize:    prize,
 					})
 				}
 				break
@@ -89,30 +97,26 @@ func AwardModePots(gameID interface{}, tableID uint32, handID uint64, mode showd
 // and will be ignored on iteration.
 //
 // This function is called once per hand, iterating all of the
-// available modes in the podium (and pots).
-func AwardPots(gameID interface{}, tableID uint32, handID uint64,
+// available modes in the podium (and pots), in the order given
+// by showdowns.ModesToCheck (i.e. high before low). The odd chips
+// policy and the button are used in each mode. When the
+// board is run more than once, this is called once per
+// board, with the part of the pots of that board.
+func AwardPots(gameID interface{}, tableID uint32, handID uint64, board uint8,
                podiums showdowns.Podiums, potSets showdowns.Pots,
+			   policy oddchips.Policy, button uint8,
 			   broadcaster *environment.Broadcaster,
 			   interval time.Duration) {
-	for mode, podium := range podiums {
-		if podium == nil {
-			broadcaster.Notify(games.GameMessage{
-				gameID,
-				tables.TableMessage{
-					tableID,
-					tables.Showdown{handID, mode, true},
-				},
-			})
+	for _, mode := range showdowns.ModesToCheck {
+		podium, ok := podiums[mode]
+		if !ok {
+			continue
+		} else if podium == nil {
+			broadcaster.NotifyTable(gameID, tableID, tables.Showdown{HandID: handID, Board: board, Mode: mode, Skipped: true})
 		} else {
-			broadcaster.Notify(games.GameMessage{
-				gameID,
-				tables.TableMessage{
-					tableID,
-					tables.Showdown{handID, mode, false},
-				},
-			})
+			broadcaster.NotifyTable(gameID, tableID, tables.Showdown{HandID: handID, Board: board, Mode: mode, Skipped: false})
 			potSet := potSets[mode]
-			AwardModePots(gameID, tableID, handID, mode, potSet, podium, broadcaster)
+			AwardModePots(gameID, tableID, handID, board, mode, potSet, podium, policy, button, broadcaster)
 			<-time.After(interval)
 		}
 	}
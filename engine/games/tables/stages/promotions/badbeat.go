@@ -0,0 +1,130 @@
+package promotions
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	messages "github.com/luismasuelli/poker-go/engine/games/messages/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+)
+
+var ErrSharesExceedPool = errors.New("the shares of the promotion exceed the whole pool")
+
+// A bad beat jackpot is hit when a strong enough hand
+// (at least a minimum power, e.g. quad eights given as
+// a Std52HighPower value) loses at showdown. When hit,
+// the pool is shared among the loser(s), the winner
+// and the other players dealt in (the table share),
+// and the rest of the pool remains as seed for the
+// next jackpot. Shares are given in basis points.
+type BadBeat struct {
+	pool          *Pool
+	minLoserPower uint64
+	bothHoleCards bool
+	loserShare    uint64
+	winnerShare   uint64
+	tableShare    uint64
+}
+
+// Creates a new bad beat jackpot over a pool, given its
+// qualifying hand, whether both hole cards must play in
+// both the losing and the winning hands, and the shares
+// (in basis points) of the loser(s), the winner and the
+// rest of the table.
+func NewBadBeat(pool *Pool, minLoserPower uint64, bothHoleCards bool,
+	loserShare, winnerShare, tableShare uint64) (*BadBeat, error) {
+	if loserShare+winnerShare+tableShare > 10000 {
+		return nil, ErrSharesExceedPool
+	}
+	return &BadBeat{pool, minLoserPower, bothHoleCards, loserShare, winnerShare, tableShare}, nil
+}
+
+// Gets the underlying pool.
+func (badBeat *BadBeat) Pool() *Pool {
+	return badBeat.pool
+}
+
+// Tells whether a losing hand qualifies for the jackpot.
+func (badBeat *BadBeat) Qualifies(hand Hand) bool {
+	return hand.Power >= badBeat.minLoserPower && (!badBeat.bothHoleCards || hand.BothHoleCardsPlay())
+}
+
+// Pays a prize to each seat, announcing it. Returns the
+// chips paid (prizes that could not be credited are not
+// counted).
+func (badBeat *BadBeat) pay(gameID interface{}, tableID uint32, handID uint64, winners []seats.Seat, prize uint64,
+	broadcaster *environment.Broadcaster) uint64 {
+	paid := uint64(0)
+	for _, seat := range winners {
+		player := seat.Player()
+		if prize == 0 || player == nil || player.Add(badBeat.pool.asset, prize) != nil {
+			continue
+		}
+		paid += prize
+		broadcaster.NotifySeat(gameID, tableID, seat.SeatID(), messages.PlayerWonPromotion{
+			Display: player.Display(),
+			HandID:  handID,
+			Prize:   prize,
+		})
+	}
+	return paid
+}
+
+// Checks whether the jackpot was hit in a hand, given the
+// standard podium of the showdown, the evaluated hands of
+// the ranked seats, and the seats dealt in. The jackpot is
+// hit when there is only one winner and the best losing
+// hand qualifies. The loser share is divided among all the
+// seats tied with the best losing hand, and the table share
+// among all the other seats dealt in. Returns whether the
+// jackpot was hit.
+func (badBeat *BadBeat) Check(gameID interface{}, tableID uint32, handID uint64, podium showdowns.Podium,
+	hands map[seats.Seat]Hand, dealtIn []seats.Seat, broadcaster *environment.Broadcaster) bool {
+	if len(podium) < 2 || len(podium[0]) != 1 || len(podium[1]) == 0 {
+		return false
+	}
+	winner := podium[0][0]
+	losers := podium[1]
+	if !badBeat.Qualifies(hands[losers[0]]) {
+		return false
+	} else if badBeat.bothHoleCards && !hands[winner].BothHoleCardsPlay() {
+		return false
+	}
+	others := make([]seats.Seat, 0, len(dealtIn))
+	involved := map[seats.Seat]bool{winner: true}
+	for _, loser := range losers {
+		involved[loser] = true
+	}
+	for _, seat := range dealtIn {
+		if _, ok := involved[seat]; !ok {
+			others = append(others, seat)
+		}
+	}
+
+	// The prizes are taken from the pool while it is locked,
+	// but credited and announced after releasing it. Prizes
+	// that could not be credited are returned to the pool.
+	badBeat.pool.mutex.Lock()
+	amount := badBeat.pool.amount
+	loserPrize := share(amount, badBeat.loserShare) / uint64(len(losers))
+	winnerPrize := share(amount, badBeat.winnerShare)
+	tablePrize := uint64(0)
+	if len(others) != 0 {
+		tablePrize = share(amount, badBeat.tableShare) / uint64(len(others))
+	}
+	taken := loserPrize*uint64(len(losers)) + winnerPrize + tablePrize*uint64(len(others))
+	badBeat.pool.amount -= taken
+	badBeat.pool.mutex.Unlock()
+
+	broadcaster.NotifyTable(gameID, tableID, tables.BadBeatJackpotHasBeenHit{HandID: handID, Amount: amount})
+	paid := badBeat.pay(gameID, tableID, handID, losers, loserPrize, broadcaster)
+	paid += badBeat.pay(gameID, tableID, handID, []seats.Seat{winner}, winnerPrize, broadcaster)
+	paid += badBeat.pay(gameID, tableID, handID, others, tablePrize, broadcaster)
+	if paid < taken {
+		badBeat.pool.mutex.Lock()
+		badBeat.pool.amount += taken - paid
+		badBeat.pool.mutex.Unlock()
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package promotions
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/players"
+)
+
+// A high hand promotion pays the whole pool to the
+// player holding the best qualifying hand shown in
+// a period (e.g. an hour). Hands are recorded as they
+// are shown, and the pool is awarded when the period
+// ends. Ties are won by the first recorded hand.
+type HighHand struct {
+	pool          *Pool
+	minPower      uint64
+	bothHoleCards bool
+	holder        players.Player
+	holderHand    Hand
+	holderHandID  uint64
+}
+
+// Creates a new high hand promotion over a pool, given
+// the minimum power of a qualifying hand and whether both
+// hole cards must play in it.
+func NewHighHand(pool *Pool, minPower uint64, bothHoleCards bool) *HighHand {
+	return &HighHand{pool: pool, minPower: minPower, bothHoleCards: bothHoleCards}
+}
+
+// Gets the underlying pool.
+func (highHand *HighHand) Pool() *Pool {
+	return highHand.pool
+}
+
+// Gets the current holder of the high hand (nil if none)
+// and the hand.
+func (highHand *HighHand) Holder() (players.Player, Hand) {
+	highHand.pool.mutex.Lock()
+	defer highHand.pool.mutex.Unlock()
+	return highHand.holder, highHand.holderHand
+}
+
+// Checks the hands shown in a showdown (ranked in the
+// standard podium, and evaluated) and records the best
+// of them if it qualifies and beats the current high
+// hand. The new high hand is announced, and this method
+// returns whether a new high hand was recorded.
+func (highHand *HighHand) Check(gameID interface{}, tableID uint32, handID uint64, podium showdowns.Podium,
+	hands map[seats.Seat]Hand, broadcaster *environment.Broadcaster) bool {
+	for _, position := range podium {
+		for _, seat := range position {
+			hand, ok := hands[seat]
+			if !ok || hand.Power < highHand.minPower || (highHand.bothHoleCards && !hand.BothHoleCardsPlay()) {
+				continue
+			}
+			highHand.pool.mutex.Lock()
+			recorded := highHand.holder == nil || hand.Power > highHand.holderHand.Power
+			if recorded {
+				highHand.holder = seat.Player()
+				highHand.holderHand = hand
+				highHand.holderHandID = handID
+			}
+			highHand.pool.mutex.Unlock()
+			if recorded {
+				broadcaster.NotifyTable(gameID, tableID, tables.HighHandHasBeenRecorded{
+					HandID: handID,
+					SeatID: seat.SeatID(),
+					Cards:  seat.Cards(true),
+				})
+			}
+			// Only the best ranked hand in the podium
+			// may become the high hand.
+			return recorded
+		}
+	}
+	return false
+}
+
+// Awards the whole pool to the holder of the high hand,
+// at the end of the period, and clears the high hand. The
+// award is announced, and the holder and the prize are
+// returned. If there is no holder, or the prize could not
+// be credited, nothing is done and (nil, 0) is returned.
+func (highHand *HighHand) Award(gameID interface{}, tableID uint32, broadcaster *environment.Broadcaster) (players.Player, uint64) {
+	// The pool and the high hand are cleared while the pool
+	// is locked, but the prize is credited and announced
+	// after releasing it. If the prize could not be credited,
+	// both are restored (unless a new high hand was recorded
+	// meanwhile).
+	highHand.pool.mutex.Lock()
+	holder, hand, handID := highHand.holder, highHand.holderHand, highHand.holderHandID
+	amount := highHand.pool.amount
+	if holder == nil || amount == 0 {
+		highHand.pool.mutex.Unlock()
+		return nil, 0
+	}
+	highHand.pool.amount = 0
+	highHand.holder = nil
+	highHand.holderHand = Hand{}
+	highHand.holderHandID = 0
+	highHand.pool.mutex.Unlock()
+
+	if holder.Add(highHand.pool.asset, amount) != nil {
+		highHand.pool.mutex.Lock()
+		highHand.pool.amount += amount
+		if highHand.holder == nil {
+			highHand.holder, highHand.holderHand, highHand.holderHandID = holder, hand, handID
+		}
+		highHand.pool.mutex.Unlock()
+		return nil, 0
+	}
+	broadcaster.NotifyTable(gameID, tableID, tables.HighHandHasBeenAwarded{
+		HandID: handID,
+		Player: holder.Display(),
+		Amount: amount,
+	})
+	return holder, amount
+}
@@ -0,0 +1,93 @@
+package promotions
+
+import (
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"sync"
+)
+
+// The evaluation of a seat's hand at showdown: the
+// flags telling which cards make the best hand, and
+// the power of that hand (as computed by the std52
+// evaluators, e.g. card7/high).
+type Hand struct {
+	Best  uint32
+	Power uint64
+}
+
+// Tells whether both hole cards play in the best
+// hand. This assumes the hole cards are the first
+// two cards given to the evaluator, which is the
+// case for Hold'Em evaluators.
+func (hand Hand) BothHoleCardsPlay() bool {
+	return hand.Best&0b11 == 0b11
+}
+
+// A promotion pool is funded by dropping a fixed
+// amount of chips from the pots of each hand, when
+// the pots are large enough. Pools are meant to be
+// shared among many tables, so they are safe for
+// concurrent use. Prizes are paid in the pool's
+// asset directly to the players' accounts.
+type Pool struct {
+	mutex  sync.Mutex
+	asset  assets.Asset
+	amount uint64
+	drop   uint64
+	minPot uint64
+}
+
+// Creates a new pool, given its asset, the initial
+// (seed) amount, the drop per hand and the minimum
+// total pot to take the drop.
+func NewPool(asset assets.Asset, seed uint64, drop uint64, minPot uint64) *Pool {
+	return &Pool{asset: asset, amount: seed, drop: drop, minPot: minPot}
+}
+
+// Gets the asset of this pool.
+func (pool *Pool) Asset() assets.Asset {
+	return pool.asset
+}
+
+// Gets the current amount of this pool.
+func (pool *Pool) Amount() uint64 {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return pool.amount
+}
+
+// Takes the drop from the collected pots of a hand
+// (from the main pot first) and adds it to the pool,
+// if the total pot is large enough. The drop is
+// announced as a table message, and returned.
+func (pool *Pool) Drop(gameID interface{}, tableID uint32, handID uint64, collected []*pots.Pot,
+	broadcaster *environment.Broadcaster) uint64 {
+	total := uint64(0)
+	for _, pot := range collected {
+		total += pot.Amount()
+	}
+	if pool.drop == 0 || total == 0 || total < pool.minPot {
+		return 0
+	}
+
+	dropped := uint64(0)
+	for _, pot := range collected {
+		if dropped == pool.drop {
+			break
+		}
+		dropped += pot.Take(pool.drop - dropped)
+	}
+	pool.mutex.Lock()
+	pool.amount += dropped
+	pool.mutex.Unlock()
+	broadcaster.NotifyTable(gameID, tableID, tables.PromotionDropHasBeenTaken{HandID: handID, Amount: dropped})
+	return dropped
+}
+
+// Takes a share (in basis points) of the given
+// amount.
+func share(amount uint64, basisPoints uint64) uint64 {
+	return amount/10000*basisPoints + amount%10000*basisPoints/10000
+}
@@ -0,0 +1,150 @@
+package promotions
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/rules/showdowns"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+func credited(seat seats.Seat) uint64 {
+	return seat.Player().(*seatstest.Player).Credited
+}
+
+func TestDrop(t *testing.T) {
+	broadcaster := environment.NewBroadcaster(nil, &seatstest.Notifiable{})
+	pool := NewPool(nil, 1000, 3, 20)
+	collected := []*pots.Pot{pots.NewPot(2, nil), pots.NewPot(18, nil)}
+	if dropped := pool.Drop(1, 1, 1, collected, broadcaster); dropped != 3 {
+		t.Errorf("expected a drop of 3, got %d", dropped)
+	}
+	if collected[0].Amount() != 0 || collected[1].Amount() != 17 || pool.Amount() != 1003 {
+		t.Errorf("unexpected amounts after the drop")
+	}
+	if dropped := pool.Drop(1, 1, 2, collected, broadcaster); dropped != 0 {
+		t.Errorf("expected no drop for small pots, got %d", dropped)
+	}
+}
+
+func TestBadBeat(t *testing.T) {
+	broadcaster := environment.NewBroadcaster(nil, &seatstest.Notifiable{})
+	if _, err := NewBadBeat(NewPool(nil, 0, 0, 0), 100, true, 5000, 3000, 3000); err != ErrSharesExceedPool {
+		t.Errorf("expected the shares to be rejected")
+	}
+	pool := NewPool(nil, 10000, 0, 0)
+	badBeat, _ := NewBadBeat(pool, 100, true, 5000, 2500, 1000)
+	s := seatstest.MakeSitting(1000, 1000, 1000, 1000)
+	podium := showdowns.Podium{{s[0]}, {s[1]}}
+	hands := map[seats.Seat]Hand{s[0]: {0b1110011, 200}, s[1]: {0b0111110, 150}}
+	if badBeat.Check(1, 1, 1, podium, hands, s, broadcaster) {
+		t.Errorf("expected the jackpot not to be hit: the loser's hole cards do not play")
+	}
+	hands[s[1]] = Hand{0b1100111, 99}
+	if badBeat.Check(1, 1, 1, podium, hands, s, broadcaster) {
+		t.Errorf("expected the jackpot not to be hit: the losing hand is not strong enough")
+	}
+	hands[s[1]] = Hand{0b1100111, 150}
+	if !badBeat.Check(1, 1, 1, podium, hands, s, broadcaster) {
+		t.Errorf("expected the jackpot to be hit")
+	}
+	if credited(s[0]) != 2500 || credited(s[1]) != 5000 || credited(s[2]) != 500 || credited(s[3]) != 500 {
+		t.Errorf("unexpected payouts: %d, %d, %d, %d", credited(s[0]), credited(s[1]), credited(s[2]), credited(s[3]))
+	}
+	if pool.Amount() != 1500 {
+		t.Errorf("expected 1500 to remain as seed, got %d", pool.Amount())
+	}
+}
+
+// Reads the pool back on each message.
+type poolReader struct {
+	pool    *Pool
+	amounts []uint64
+}
+
+func (reader *poolReader) Notify(message interface{}) {
+	reader.amounts = append(reader.amounts, reader.pool.Amount())
+}
+
+func TestBadBeatReleasesThePool(t *testing.T) {
+	pool := NewPool(nil, 10000, 0, 0)
+	reader := &poolReader{pool: pool}
+	broadcaster := environment.NewBroadcaster(nil, reader)
+	badBeat, _ := NewBadBeat(pool, 100, false, 5000, 2500, 1000)
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	podium := showdowns.Podium{{s[0]}, {s[1]}}
+	hands := map[seats.Seat]Hand{s[0]: {0b1110011, 200}, s[1]: {0b1100111, 150}}
+	if !badBeat.Check(1, 1, 1, podium, hands, s, broadcaster) {
+		t.Errorf("expected the jackpot to be hit")
+	}
+	// The listener reads the pool, already without the
+	// prizes, while they are announced.
+	if len(reader.amounts) != 4 || reader.amounts[0] != 1500 || pool.Amount() != 1500 {
+		t.Errorf("unexpected pool amounts: %v, %d", reader.amounts, pool.Amount())
+	}
+}
+
+func TestHighHand(t *testing.T) {
+	broadcaster := environment.NewBroadcaster(nil, &seatstest.Notifiable{})
+	pool := NewPool(nil, 500, 0, 0)
+	highHand := NewHighHand(pool, 100, false)
+	s := seatstest.MakeSitting(1000, 1000, 1000)
+	if highHand.Check(1, 1, 1, showdowns.Podium{{s[0]}, {s[1]}}, map[seats.Seat]Hand{s[0]: {0, 90}, s[1]: {0, 80}}, broadcaster) {
+		t.Errorf("expected no high hand to be recorded")
+	}
+	if !highHand.Check(1, 1, 2, showdowns.Podium{{s[1]}, {s[0]}}, map[seats.Seat]Hand{s[1]: {0, 120}, s[0]: {0, 110}}, broadcaster) {
+		t.Errorf("expected a high hand to be recorded")
+	}
+	if highHand.Check(1, 1, 3, showdowns.Podium{{s[2]}}, map[seats.Seat]Hand{s[2]: {0, 120}}, broadcaster) {
+		t.Errorf("expected a tie not to replace the high hand")
+	}
+	if player, amount := highHand.Award(1, 1, broadcaster); player != s[1].Player() || amount != 500 {
+		t.Errorf("expected the second seat to be awarded 500, got %d", amount)
+	}
+	if holder, _ := highHand.Holder(); holder != nil || pool.Amount() != 0 || credited(s[1]) != 500 {
+		t.Errorf("expected the high hand to be cleared after the award")
+	}
+}
+
+// A player whose account refuses the prizes.
+type refusingPlayer struct {
+	seatstest.Player
+}
+
+func (player *refusingPlayer) Add(asset assets.Asset, amount uint64) error {
+	return errors.New("account closed")
+}
+
+func TestHighHandReleasesThePool(t *testing.T) {
+	pool := NewPool(nil, 500, 0, 0)
+	reader := &poolReader{pool: pool}
+	broadcaster := environment.NewBroadcaster(nil, reader)
+	highHand := NewHighHand(pool, 100, false)
+	s := seatstest.MakeSitting(1000, 1000)
+	s[1].Pop()
+	s[1].Sit(&refusingPlayer{}, 1000)
+
+	// The prize cannot be credited: the pool and the high
+	// hand are kept.
+	highHand.Check(1, 1, 1, showdowns.Podium{{s[1]}}, map[seats.Seat]Hand{s[1]: {0, 120}}, broadcaster)
+	if player, amount := highHand.Award(1, 1, broadcaster); player != nil || amount != 0 {
+		t.Errorf("expected nothing to be awarded, got %d", amount)
+	}
+	if holder, _ := highHand.Holder(); holder != s[1].Player() || pool.Amount() != 500 {
+		t.Errorf("expected the pool and the high hand to be kept")
+	}
+
+	// The listener reads the pool, already without the
+	// prize, while the award is announced.
+	highHand.Check(1, 1, 2, showdowns.Podium{{s[0]}}, map[seats.Seat]Hand{s[0]: {0, 130}}, broadcaster)
+	reader.amounts = nil
+	if player, amount := highHand.Award(1, 1, broadcaster); player != s[0].Player() || amount != 500 {
+		t.Errorf("expected the first seat to be awarded 500, got %d", amount)
+	}
+	if len(reader.amounts) != 1 || reader.amounts[0] != 0 || credited(s[0]) != 500 {
+		t.Errorf("unexpected pool amounts: %v", reader.amounts)
+	}
+}
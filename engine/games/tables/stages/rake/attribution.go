@@ -0,0 +1,121 @@
+package rake
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/misc"
+	"sort"
+)
+
+// Attributes the rake taken from each pot to the seats
+// that contributed to that pot, in proportion to their
+// contributions (this is the "contributed" method). The
+// remaining chips of each pot's rake are attributed one
+// by one to the greatest contributors first.
+//
+// Pots not keeping track of contributions attribute
+// their rake evenly among their involved seats.
+func AttributeContributed(collected []*pots.Pot, taken []uint64) map[seats.Seat]uint64 {
+	shares := map[seats.Seat]uint64{}
+	for index, pot := range collected {
+		if index >= len(taken) || taken[index] == 0 {
+			continue
+		}
+		contributions := pot.Contributions()
+		if len(contributions) == 0 {
+			for seat, chips := range AttributeDealt(taken[index], pot.Seats()) {
+				shares[seat] += chips
+			}
+			continue
+		}
+
+		contributors := make([]seats.Seat, 0, len(contributions))
+		total := uint64(0)
+		for seat, chips := range contributions {
+			contributors = append(contributors, seat)
+			total += chips
+		}
+		sort.Slice(contributors, func(i, j int) bool {
+			ci, cj := contributions[contributors[i]], contributions[contributors[j]]
+			return ci > cj || (ci == cj && contributors[i].SeatID() < contributors[j].SeatID())
+		})
+		remaining := taken[index]
+		for _, seat := range contributors {
+			share := proportion(taken[index], contributions[seat], total)
+			shares[seat] += share
+			remaining -= share
+		}
+		for _, seat := range contributors {
+			if remaining == 0 {
+				break
+			}
+			shares[seat]++
+			remaining--
+		}
+	}
+	return shares
+}
+
+// Attributes the whole rake evenly among the seats that
+// were dealt in (this is the "dealt" method). The
+// remaining chips are attributed one by one to the
+// first seats, in the given order.
+func AttributeDealt(total uint64, dealtIn []seats.Seat) map[seats.Seat]uint64 {
+	shares := map[seats.Seat]uint64{}
+	count := uint64(len(dealtIn))
+	if count == 0 || total == 0 {
+		return shares
+	}
+	for index, seat := range dealtIn {
+		shares[seat] = total / count
+		if uint64(index) < total%count {
+			shares[seat]++
+		}
+	}
+	return shares
+}
+
+// Attributes the rake of a hand to each of the players
+// dealt in, under both the "contributed" and the "dealt"
+// methods, and sends the result to the listener (e.g.
+// an accounting layer computing rakeback and loyalty
+// points) as a table message. The pots are the ones the
+// rake was taken from, and the taken chips are the ones
+// returned when taking the rake.
+//
+// The shares are returned in the order of the dealt in
+// seats, and only seats dealt in are considered.
+func Attribute(gameID interface{}, tableID uint32, handID uint64, collected []*pots.Pot, taken []uint64,
+	dealtIn []seats.Seat, listener misc.Notifiable) []tables.RakeShare {
+	total := uint64(0)
+	for _, chips := range taken {
+		total += chips
+	}
+	contributed := AttributeContributed(collected, taken)
+	dealt := AttributeDealt(total, dealtIn)
+	shares := make([]tables.RakeShare, len(dealtIn))
+	for index, seat := range dealtIn {
+		var player interface{}
+		if seatPlayer := seat.Player(); seatPlayer != nil {
+			player = seatPlayer.Identification()
+		}
+		shares[index] = tables.RakeShare{
+			SeatID:      seat.SeatID(),
+			Player:      player,
+			Contributed: contributed[seat],
+			Dealt:       dealt[seat],
+		}
+	}
+	if listener != nil && total != 0 {
+		listener.Notify(games.GameMessage{
+			GameID: gameID,
+			Content: tables.TableMessage{
+				TableID: tableID,
+				Content: tables.RakeHasBeenAttributed{HandID: handID, Shares: shares},
+			},
+		})
+	}
+	return shares
+}
@@ -0,0 +1,74 @@
+package rake
+
+import (
+	"github.com/luismasuelli/poker-go/engine/games/messages/games"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats"
+	"github.com/luismasuelli/poker-go/engine/games/tables/seats/seatstest"
+	"testing"
+)
+
+// Creates active seats with the given bets (out of
+// 5000 chips).
+func makeSeats(bets ...uint64) []seats.Seat {
+	result := seatstest.MakeActive(seatstest.Stacks(len(bets), 5000)...)
+	for index, chips := range bets {
+		seat := result[index]
+		seat.SubStack(chips)
+		seat.AddPot(chips)
+		if seat.Stack() == 0 {
+			seat.SetStatus(seats.AllIn)
+		}
+	}
+	return result
+}
+
+func TestAttributeDealt(t *testing.T) {
+	s := makeSeats(0, 0, 0)
+	shares := AttributeDealt(10, s)
+	if shares[s[0]] != 4 || shares[s[1]] != 3 || shares[s[2]] != 3 {
+		t.Errorf("unexpected dealt shares: %d, %d, %d", shares[s[0]], shares[s[1]], shares[s[2]])
+	}
+}
+
+func TestAttribute(t *testing.T) {
+	// The first seat is all-in for 1000, so there is a
+	// main pot of 3000 and a side pot of 2000.
+	s := makeSeats(1000, 2000, 2000, 0)
+	s[0].SetStatus(seats.AllIn)
+	collected, _, _ := pots.Collect(nil, s)
+	parent := &dummyNotifiable{}
+	taken, _ := NewRake(100, 0, false).Take(1, 1, 1, collected, 4, true, &dummyHouse{}, nil,
+		environment.NewBroadcaster(nil, parent))
+	if taken[0] != 30 || taken[1] != 20 {
+		t.Errorf("unexpected rake: %v", taken)
+		return
+	}
+	listener := &dummyNotifiable{}
+	shares := Attribute(1, 1, 1, collected, taken, s, listener)
+	expected := [][2]uint64{{10, 13}, {20, 13}, {20, 12}, {0, 12}}
+	for index, share := range shares {
+		if share.Contributed != expected[index][0] || share.Dealt != expected[index][1] {
+			t.Errorf("seat %d: expected %v, got %d/%d", share.SeatID, expected[index], share.Contributed, share.Dealt)
+		}
+	}
+	if len(listener.messages) != 1 {
+		t.Errorf("expected the attribution to be notified")
+		return
+	}
+	message := listener.messages[0].(games.GameMessage).Content.(tables.TableMessage).Content
+	if attributed, ok := message.(tables.RakeHasBeenAttributed); !ok || attributed.Shares[1].Player != s[1].Player().Identification() {
+		t.Errorf("unexpected attribution message: %v", message)
+	}
+}
+
+func TestAttributeContributedRemainders(t *testing.T) {
+	s := makeSeats(10, 10, 10)
+	collected, _, _ := pots.Collect(nil, s)
+	shares := AttributeContributed(collected, []uint64{2})
+	if shares[s[0]] != 1 || shares[s[1]] != 1 || shares[s[2]] != 0 {
+		t.Errorf("unexpected contributed shares: %d, %d, %d", shares[s[0]], shares[s[1]], shares[s[2]])
+	}
+}
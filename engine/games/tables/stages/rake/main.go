@@ -0,0 +1,148 @@
+package rake
+
+import (
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/messages/games/tables"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"math/bits"
+	"sort"
+)
+
+// Computes (a * b) / c without overflowing, provided
+// that b <= c (which is always the case when taking
+// a proportion of an amount).
+func proportion(a, b, c uint64) uint64 {
+	if c == 0 {
+		return 0
+	}
+	hi, lo := bits.Mul64(a, b)
+	quotient, _ := bits.Div64(hi, lo, c)
+	return quotient
+}
+
+// A rake cap applies when at least a certain number
+// of players were dealt in the hand.
+type Cap struct {
+	Players uint8
+	Amount  uint64
+}
+
+// Rake is the amount of chips the house takes from
+// each hand in cash tables. It is a percentage of
+// the pots (given in basis points: 500 means 5%),
+// which may be capped depending on the number of
+// players dealt in. Pots smaller than a minimum are
+// not raked, and optionally hands that ended before
+// the flop are not raked (no-flop-no-drop).
+type Rake struct {
+	percentage   uint64
+	minPot       uint64
+	noFlopNoDrop bool
+	caps         []Cap
+}
+
+// Creates a new rake, with its percentage (in basis
+// points), the minimum pot to be raked, whether the
+// no-flop-no-drop rule is used, and the caps.
+func NewRake(percentage uint64, minPot uint64, noFlopNoDrop bool, caps ...Cap) *Rake {
+	if percentage > 10000 {
+		percentage = 10000
+	}
+	sortedCaps := append(make([]Cap, 0, len(caps)), caps...)
+	sort.Slice(sortedCaps, func(i, j int) bool {
+		return sortedCaps[i].Players < sortedCaps[j].Players
+	})
+	return &Rake{percentage, minPot, noFlopNoDrop, sortedCaps}
+}
+
+// Gets the percentage, in basis points.
+func (rake *Rake) Percentage() uint64 {
+	return rake.percentage
+}
+
+// Gets the minimum pot to be raked.
+func (rake *Rake) MinPot() uint64 {
+	return rake.minPot
+}
+
+// Tells whether the no-flop-no-drop rule is used.
+func (rake *Rake) NoFlopNoDrop() bool {
+	return rake.noFlopNoDrop
+}
+
+// Gets the cap that applies when the given number
+// of players were dealt in: the one having the
+// greatest number of players not exceeding it. A
+// result of 0 means there is no cap.
+func (rake *Rake) Cap(dealtIn uint8) uint64 {
+	amount := uint64(0)
+	for _, cap := range rake.caps {
+		if cap.Players > dealtIn {
+			break
+		}
+		amount = cap.Amount
+	}
+	return amount
+}
+
+// Computes the rake for a total amount of chips in
+// the pots, the number of players dealt in, and
+// whether the flop was seen (games with no flop
+// should tell whether the hand went beyond its
+// first betting round instead).
+func (rake *Rake) Compute(total uint64, dealtIn uint8, flopSeen bool) uint64 {
+	if total == 0 || total < rake.minPot || (rake.noFlopNoDrop && !flopSeen) {
+		return 0
+	}
+	amount := proportion(total, rake.percentage, 10000)
+	if cap := rake.Cap(dealtIn); cap != 0 && amount > cap {
+		amount = cap
+	}
+	return amount
+}
+
+// Takes the rake from the collected pots, before they
+// are split and awarded. The rake is taken from each
+// pot proportionally to its amount, and the remaining
+// chips are taken from the main pot first. The whole
+// rake is credited to the house (in the asset of the
+// table) and announced as a table message.
+//
+// The rake taken from each pot is returned, in the
+// same order of the pots. If the rake could not be
+// credited to the house, no rake is taken and the
+// error is returned.
+func (rake *Rake) Take(gameID interface{}, tableID uint32, handID uint64, collected []*pots.Pot,
+	dealtIn uint8, flopSeen bool, house players.Accounting, asset assets.Asset,
+	broadcaster *environment.Broadcaster) ([]uint64, error) {
+	total := uint64(0)
+	for _, pot := range collected {
+		total += pot.Amount()
+	}
+	taken := make([]uint64, len(collected))
+	amount := rake.Compute(total, dealtIn, flopSeen)
+	if amount == 0 {
+		return taken, nil
+	}
+	if err := house.Add(asset, amount); err != nil {
+		return taken, err
+	}
+
+	remaining := amount
+	for index, pot := range collected {
+		taken[index] = pot.Take(proportion(amount, pot.Amount(), total))
+		remaining -= taken[index]
+	}
+	for index, pot := range collected {
+		if remaining == 0 {
+			break
+		}
+		chips := pot.Take(remaining)
+		taken[index] += chips
+		remaining -= chips
+	}
+	broadcaster.NotifyTable(gameID, tableID, tables.RakeHasBeenTaken{HandID: handID, Amount: amount})
+	return taken, nil
+}
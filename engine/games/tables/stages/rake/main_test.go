@@ -0,0 +1,94 @@
+package rake
+
+import (
+	"errors"
+	"github.com/luismasuelli/poker-go/engine/assets"
+	"github.com/luismasuelli/poker-go/engine/games/tables/environment"
+	"github.com/luismasuelli/poker-go/engine/games/tables/pots"
+	"github.com/luismasuelli/poker-go/engine/players"
+	"testing"
+)
+
+type dummyHouse struct {
+	total uint64
+	fail  bool
+}
+
+func (house *dummyHouse) FindHaving(asset assets.Asset) []players.Accounting { return nil }
+func (house *dummyHouse) Get(asset assets.Asset) (uint64, error)             { return house.total, nil }
+func (house *dummyHouse) Take(asset assets.Asset, amount uint64) error       { return nil }
+func (house *dummyHouse) Add(asset assets.Asset, amount uint64) error {
+	if house.fail {
+		return errors.New("cannot add")
+	}
+	house.total += amount
+	return nil
+}
+
+type dummyNotifiable struct {
+	messages []interface{}
+}
+
+func (notifiable *dummyNotifiable) Notify(message interface{}) {
+	notifiable.messages = append(notifiable.messages, message)
+}
+
+func testCompute(t *testing.T, rake *Rake, total uint64, dealtIn uint8, flopSeen bool, expected uint64) {
+	if amount := rake.Compute(total, dealtIn, flopSeen); amount != expected {
+		t.Errorf("raking %d (%d players, flop: %v): expected %d, got %d", total, dealtIn, flopSeen, expected, amount)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	rake := NewRake(500, 20, true, Cap{2, 5}, Cap{4, 20}, Cap{3, 10})
+	testCompute(t, rake, 100, 2, true, 5)
+	testCompute(t, rake, 100, 3, true, 5)
+	testCompute(t, rake, 1000, 2, true, 5)
+	testCompute(t, rake, 1000, 3, true, 10)
+	testCompute(t, rake, 1000, 9, true, 20)
+	testCompute(t, rake, 1000, 9, false, 0)
+	testCompute(t, rake, 19, 9, true, 0)
+	testCompute(t, rake, 39, 9, true, 1)
+	uncapped := NewRake(1000, 0, false)
+	testCompute(t, uncapped, 1000, 9, false, 100)
+	testCompute(t, uncapped, ^uint64(0), 9, false, ^uint64(0)/10)
+}
+
+func TestTake(t *testing.T) {
+	parent := &dummyNotifiable{}
+	broadcaster := environment.NewBroadcaster(nil, parent)
+	house := &dummyHouse{}
+	collected := []*pots.Pot{pots.NewPot(301, nil), pots.NewPot(100, nil), pots.NewPot(5, nil)}
+	taken, err := NewRake(500, 0, false).Take(1, 1, 1, collected, 6, true, house, nil, broadcaster)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if house.total != 20 {
+		t.Errorf("expected the house to get 20, got %d", house.total)
+	}
+	expected := []uint64{16, 4, 0}
+	for index, chips := range taken {
+		if chips != expected[index] {
+			t.Errorf("expected %d chips taken from pot %d, got %d", expected[index], index, chips)
+		}
+	}
+	if collected[0].Amount() != 285 || collected[1].Amount() != 96 || collected[2].Amount() != 5 {
+		t.Errorf("unexpected pot amounts after rake")
+	}
+	if len(parent.messages) != 1 {
+		t.Errorf("expected the rake to be announced")
+	}
+}
+
+func TestTakeFailure(t *testing.T) {
+	parent := &dummyNotifiable{}
+	broadcaster := environment.NewBroadcaster(nil, parent)
+	collected := []*pots.Pot{pots.NewPot(100, nil)}
+	if _, err := NewRake(500, 0, false).Take(1, 1, 1, collected, 6, true, &dummyHouse{fail: true}, nil, broadcaster); err == nil {
+		t.Errorf("expected an error")
+	}
+	if collected[0].Amount() != 100 || len(parent.messages) != 0 {
+		t.Errorf("expected no rake to be taken")
+	}
+}
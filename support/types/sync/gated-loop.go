@@ -79,3 +79,10 @@ func (gated *GatedLoop) Gate() *Gate {
 func NewGated(before func(), while func() bool, do func(), after func(panicked interface{})) *GatedLoop {
 	return &GatedLoop{NewGate(), Once{}, before, while, do, after, Created}
 }
+
+// Creates a gated runner on an existing gate (e.g. to run
+// a new loop after a former one ended, keeping the same
+// witnesses), with optional before/after callbacks.
+func NewGatedOn(gate *Gate, before func(), while func() bool, do func(), after func(panicked interface{})) *GatedLoop {
+	return &GatedLoop{gate, Once{}, before, while, do, after, Created}
+}